@@ -3,8 +3,17 @@ package model
 import "time"
 
 type Achievement struct {
-	ID        string    `json:"id"`
-	StudentID string    `json:"student_id"`
-	Status    string    `json:"status"`
-	CreatedAt time.Time `json:"created_at"`
+	ID          string     `json:"id"`
+	StudentID   string     `json:"student_id"`
+	Status      string     `json:"status"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	SubmittedAt *time.Time `json:"submitted_at,omitempty"`
+}
+
+// AchievementRefLink pasangan reference Postgres <-> dokumen Mongo (dipakai reconcile)
+type AchievementRefLink struct {
+	RefID   string
+	MongoID string
+	Status  string
 }
@@ -0,0 +1,50 @@
+package model
+
+// BulkVerifyItem id + poin dan tahap persetujuan yang sudah dihitung service
+type BulkVerifyItem struct {
+	ID     string
+	Points int
+	Stages []ApprovalStage
+}
+
+// BulkVerifyRequest payload POST /achievements/bulk/verify
+type BulkVerifyRequest struct {
+	IDs    []string `json:"ids" example:"550e8400-e29b-41d4-a716-446655440000,6ba7b810-9dad-11d1-80b4-00c04fd430c8"`
+	Atomic bool     `json:"atomic" example:"false"`
+}
+
+// BulkRejectRequest payload POST /achievements/bulk/reject (note dipakai untuk semua item)
+type BulkRejectRequest struct {
+	IDs    []string `json:"ids" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Note   string   `json:"note" example:"Bukti kurang jelas"`
+	Atomic bool     `json:"atomic" example:"false"`
+}
+
+// status hasil per item
+const (
+	BulkItemSucceeded  = "succeeded"
+	BulkItemFailed     = "failed"
+	BulkItemRolledBack = "rolled_back" // atomic: sukses tapi ikut dibatalkan / tidak sempat diproses
+)
+
+type BulkItemResult struct {
+	ID      string  `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Result  string  `json:"result" example:"failed"`
+	Status  string  `json:"status,omitempty" example:"verified"`
+	Points  *int    `json:"points,omitempty" example:"50"`
+	Code    int     `json:"code" example:"409"`
+	Message *string `json:"message,omitempty" example:"cannot verify from status draft"`
+}
+
+type BulkActionReport struct {
+	Action    string           `json:"action" example:"verify"`
+	Atomic    bool             `json:"atomic" example:"false"`
+	Committed bool             `json:"committed" example:"true"`
+	Succeeded int              `json:"succeeded" example:"78"`
+	Failed    int              `json:"failed" example:"2"`
+	Results   []BulkItemResult `json:"results"`
+}
+
+type BulkActionResponse struct {
+	Data BulkActionReport `json:"data"`
+}
@@ -0,0 +1,41 @@
+package model
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const CommentMaxLength = 2000
+
+// AchievementComment satu komentar di thread diskusi prestasi
+type AchievementComment struct {
+	ID         string `json:"id" example:"7c9e6679-7425-40de-944b-e07fc1f90ae7"`
+	AuthorID   string `json:"authorId" example:"550e8400-e29b-41d4-a716-446655440000"`
+	AuthorName string `json:"authorName" example:"Dr. Sari"`
+	AuthorRole string `json:"authorRole" example:"Dosen Wali"`
+	Body       string `json:"body" example:"Mohon lampirkan sertifikat versi asli."`
+	// lampiran yang dibahas (opsional)
+	AttachmentID *string   `json:"attachmentId,omitempty" example:"3f1b2c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+type AchievementCommentRequest struct {
+	Body         string  `json:"body" example:"Mohon lampirkan sertifikat versi asli."`
+	AttachmentID *string `json:"attachmentId,omitempty" example:"3f1b2c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d"`
+}
+
+func (r *AchievementCommentRequest) Validate() []FieldError {
+	var errs []FieldError
+	body := strings.TrimSpace(r.Body)
+	switch {
+	case body == "":
+		errs = append(errs, FieldError{Field: "body", Message: "is required"})
+	case utf8.RuneCountInString(body) > CommentMaxLength:
+		errs = append(errs, FieldError{Field: "body", Message: "must be at most 2000 characters"})
+	}
+	if r.AttachmentID != nil && strings.TrimSpace(*r.AttachmentID) == "" {
+		errs = append(errs, FieldError{Field: "attachmentId", Message: "must not be empty"})
+	}
+	return errs
+}
@@ -0,0 +1,317 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ===== Validation =====
+
+// FieldError satu error validasi per field (path pakai notasi titik, mis. details.level)
+type FieldError struct {
+	Field   string `json:"field" example:"details.level"`
+	Message string `json:"message" example:"must be one of: international, national, regional, local"`
+}
+
+type fieldErrors []FieldError
+
+func (e *fieldErrors) add(field, message string) {
+	*e = append(*e, FieldError{Field: "details." + field, Message: message})
+}
+
+func (e *fieldErrors) required(field, value string) {
+	if strings.TrimSpace(value) == "" {
+		e.add(field, "is required")
+	}
+}
+
+func (e *fieldErrors) date(field, value string, required bool) {
+	if value == "" {
+		if required {
+			e.add(field, "is required")
+		}
+		return
+	}
+	if _, err := time.Parse(dateLayout, value); err != nil {
+		e.add(field, "must be a date (YYYY-MM-DD)")
+	}
+}
+
+func (e *fieldErrors) oneOf(field, value string, required bool, options ...string) {
+	if value == "" {
+		if required {
+			e.add(field, "is required")
+		}
+		return
+	}
+	for _, o := range options {
+		if value == o {
+			return
+		}
+	}
+	e.add(field, "must be one of: "+strings.Join(options, ", "))
+}
+
+const dateLayout = "2006-01-02"
+
+// ===== Detail schemas =====
+
+// AchievementDetails detail bertipe untuk satu achievementType.
+type AchievementDetails interface {
+	Validate() []FieldError
+	// DetailLevel tingkat prestasi (kosong jika tipe tidak punya level)
+	DetailLevel() string
+}
+
+type CompetitionDetails struct {
+	CompetitionName string `json:"competitionName"`
+	Level           string `json:"level"`
+	Rank            int    `json:"rank,omitempty"`
+	MedalType       string `json:"medalType,omitempty"`
+	Organizer       string `json:"organizer"`
+	EventDate       string `json:"eventDate"`
+	Location        string `json:"location,omitempty"`
+}
+
+func (d *CompetitionDetails) Validate() []FieldError {
+	var e fieldErrors
+	e.required("competitionName", d.CompetitionName)
+	e.required("level", d.Level)
+	if d.Rank < 0 {
+		e.add("rank", "must be >= 1")
+	}
+	e.oneOf("medalType", d.MedalType, false, "gold", "silver", "bronze")
+	e.required("organizer", d.Organizer)
+	e.date("eventDate", d.EventDate, true)
+	return e
+}
+
+func (d *CompetitionDetails) DetailLevel() string { return d.Level }
+
+type PublicationDetails struct {
+	PublicationType string   `json:"publicationType"`
+	Journal         string   `json:"journal"`
+	Authors         []string `json:"authors"`
+	DOI             string   `json:"doi,omitempty"`
+	Publisher       string   `json:"publisher,omitempty"`
+	ISSN            string   `json:"issn,omitempty"`
+	PublishedAt     string   `json:"publishedAt"`
+	Level           string   `json:"level,omitempty"`
+}
+
+var (
+	doiPattern  = regexp.MustCompile(`^10\.\d{4,9}/\S+$`)
+	issnPattern = regexp.MustCompile(`^\d{4}-\d{3}[\dXx]$`)
+)
+
+func (d *PublicationDetails) Validate() []FieldError {
+	var e fieldErrors
+	e.oneOf("publicationType", d.PublicationType, true, "journal", "conference", "book")
+	e.required("journal", d.Journal)
+	if len(d.Authors) == 0 {
+		e.add("authors", "must contain at least one author")
+	}
+	for _, a := range d.Authors {
+		if strings.TrimSpace(a) == "" {
+			e.add("authors", "must not contain empty names")
+			break
+		}
+	}
+	if d.DOI != "" && !doiPattern.MatchString(d.DOI) {
+		e.add("doi", "must be a DOI (10.xxxx/...)")
+	}
+	if d.ISSN != "" && !issnPattern.MatchString(d.ISSN) {
+		e.add("issn", "must be an ISSN (1234-567X)")
+	}
+	e.date("publishedAt", d.PublishedAt, true)
+	return e
+}
+
+func (d *PublicationDetails) DetailLevel() string { return d.Level }
+
+type OrganizationDetails struct {
+	OrganizationName string `json:"organizationName"`
+	Position         string `json:"position"`
+	PeriodStart      string `json:"periodStart"`
+	PeriodEnd        string `json:"periodEnd,omitempty"`
+	Level            string `json:"level,omitempty"`
+}
+
+func (d *OrganizationDetails) Validate() []FieldError {
+	var e fieldErrors
+	e.required("organizationName", d.OrganizationName)
+	e.required("position", d.Position)
+	e.date("periodStart", d.PeriodStart, true)
+	e.date("periodEnd", d.PeriodEnd, false)
+	if d.PeriodStart != "" && d.PeriodEnd != "" && d.PeriodEnd < d.PeriodStart {
+		e.add("periodEnd", "must not be before periodStart")
+	}
+	return e
+}
+
+func (d *OrganizationDetails) DetailLevel() string { return d.Level }
+
+type CertificationDetails struct {
+	CertificationName   string `json:"certificationName"`
+	IssuedBy            string `json:"issuedBy"`
+	CertificationNumber string `json:"certificationNumber,omitempty"`
+	IssuedAt            string `json:"issuedAt"`
+	ValidUntil          string `json:"validUntil,omitempty"`
+}
+
+func (d *CertificationDetails) Validate() []FieldError {
+	var e fieldErrors
+	e.required("certificationName", d.CertificationName)
+	e.required("issuedBy", d.IssuedBy)
+	e.date("issuedAt", d.IssuedAt, true)
+	e.date("validUntil", d.ValidUntil, false)
+	if d.IssuedAt != "" && d.ValidUntil != "" && d.ValidUntil < d.IssuedAt {
+		e.add("validUntil", "must not be before issuedAt")
+	}
+	return e
+}
+
+func (d *CertificationDetails) DetailLevel() string { return "" }
+
+type AcademicDetails struct {
+	AwardName string   `json:"awardName"`
+	IssuedBy  string   `json:"issuedBy"`
+	Level     string   `json:"level,omitempty"`
+	EventDate string   `json:"eventDate"`
+	Score     *float64 `json:"score,omitempty"`
+}
+
+func (d *AcademicDetails) Validate() []FieldError {
+	var e fieldErrors
+	e.required("awardName", d.AwardName)
+	e.required("issuedBy", d.IssuedBy)
+	e.date("eventDate", d.EventDate, true)
+	if d.Score != nil && *d.Score < 0 {
+		e.add("score", "must be >= 0")
+	}
+	return e
+}
+
+func (d *AcademicDetails) DetailLevel() string { return d.Level }
+
+type OtherDetails struct {
+	ActivityName string            `json:"activityName"`
+	EventDate    string            `json:"eventDate"`
+	Organizer    string            `json:"organizer,omitempty"`
+	Location     string            `json:"location,omitempty"`
+	CustomFields map[string]string `json:"customFields,omitempty"`
+}
+
+func (d *OtherDetails) Validate() []FieldError {
+	var e fieldErrors
+	e.required("activityName", d.ActivityName)
+	e.date("eventDate", d.EventDate, true)
+	return e
+}
+
+func (d *OtherDetails) DetailLevel() string { return "" }
+
+// ===== Registry =====
+
+// AchievementDetailSchema skema details dan pembuat struct-nya.
+// Tipe di katalog (achievement_types) menunjuk salah satu skema ini; daftar level-nya dari katalog.
+type AchievementDetailSchema struct {
+	Code       string
+	Name       string
+	NewDetails func() AchievementDetails
+}
+
+var AchievementDetailSchemas = map[string]AchievementDetailSchema{
+	"competition":   {Code: "competition", Name: "Kompetisi", NewDetails: func() AchievementDetails { return &CompetitionDetails{} }},
+	"publication":   {Code: "publication", Name: "Publikasi", NewDetails: func() AchievementDetails { return &PublicationDetails{} }},
+	"organization":  {Code: "organization", Name: "Organisasi", NewDetails: func() AchievementDetails { return &OrganizationDetails{} }},
+	"certification": {Code: "certification", Name: "Sertifikasi", NewDetails: func() AchievementDetails { return &CertificationDetails{} }},
+	"academic":      {Code: "academic", Name: "Akademik", NewDetails: func() AchievementDetails { return &AcademicDetails{} }},
+	"other":         {Code: "other", Name: "Lainnya", NewDetails: func() AchievementDetails { return &OtherDetails{} }},
+}
+
+// ParseAchievementDetails decode details mentah ke struct sesuai skema lalu validasi.
+// Mengembalikan details yang sudah dinormalisasi (field tak dikenal ditolak).
+func ParseAchievementDetails(schemaCode string, raw map[string]interface{}) (AchievementDetails, map[string]interface{}, []FieldError) {
+	schema, ok := AchievementDetailSchemas[schemaCode]
+	if !ok {
+		return nil, nil, []FieldError{{Field: "achievementType", Message: "unknown details schema"}}
+	}
+	if len(raw) == 0 {
+		return nil, nil, []FieldError{{Field: "details", Message: "is required"}}
+	}
+
+	details := schema.NewDetails()
+	b, err := json.Marshal(raw)
+	if err != nil {
+		return nil, nil, []FieldError{{Field: "details", Message: "invalid details"}}
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(details); err != nil {
+		return nil, nil, []FieldError{decodeFieldError(err)}
+	}
+
+	if errs := details.Validate(); len(errs) > 0 {
+		return nil, nil, errs
+	}
+
+	normalized := map[string]interface{}{}
+	b, _ = json.Marshal(details)
+	_ = json.Unmarshal(b, &normalized)
+	return details, normalized, nil
+}
+
+func decodeFieldError(err error) FieldError {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return FieldError{Field: "details." + typeErr.Field, Message: "must be " + typeErr.Type.String()}
+	}
+	msg := err.Error()
+	if strings.HasPrefix(msg, "json: unknown field ") {
+		name := strings.Trim(strings.TrimPrefix(msg, "json: unknown field "), `"`)
+		return FieldError{Field: "details." + name, Message: "unknown field"}
+	}
+	return FieldError{Field: "details", Message: msg}
+}
+
+// ValidateAchievementUpsert validasi field utama + details sesuai skema tipe dari katalog.
+// t nil = achievementType tidak ada / tidak aktif di katalog.
+func ValidateAchievementUpsert(req *AchievementUpsertRequest, t *AchievementType) (map[string]interface{}, []FieldError) {
+	var errs []FieldError
+	switch {
+	case strings.TrimSpace(req.AchievementType) == "":
+		errs = append(errs, FieldError{Field: "achievementType", Message: "is required"})
+	case t == nil:
+		errs = append(errs, FieldError{Field: "achievementType", Message: "unknown achievement type"})
+	}
+	if strings.TrimSpace(req.Title) == "" {
+		errs = append(errs, FieldError{Field: "title", Message: "is required"})
+	}
+	if strings.TrimSpace(req.Description) == "" {
+		errs = append(errs, FieldError{Field: "description", Message: "is required"})
+	}
+	if t == nil {
+		return nil, errs
+	}
+
+	d, details, detailErrs := ParseAchievementDetails(t.Schema, req.Details)
+	errs = append(errs, detailErrs...)
+	if d != nil {
+		if level := d.DetailLevel(); level != "" && !t.HasLevel(level) {
+			msg := "is not available for this achievement type"
+			if len(t.Levels) > 0 {
+				msg = "must be one of: " + strings.Join(t.Levels, ", ")
+			}
+			errs = append(errs, FieldError{Field: "details.level", Message: msg})
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return details, nil
+}
@@ -0,0 +1,68 @@
+package model
+
+import "time"
+
+// AchievementScope batas data yang boleh dilihat user (sesuai role).
+type AchievementScope struct {
+	Role   string
+	UserID string
+}
+
+// AchievementSummary salinan field Mongo di achievement_references (achievement_type, points, tags)
+// untuk filter list; ditulis bersama entry outbox yang mengubah field tersebut.
+type AchievementSummary struct {
+	AchievementType string
+	Points          int
+	Tags            []string
+}
+
+// AchievementFilter query GET /achievements.
+// AchievementType, Tags, MinPoints, MaxPoints memakai salinan di achievement_references (AchievementSummary).
+type AchievementFilter struct {
+	Status          []string
+	AchievementType string
+	Student         string // students.id atau NIM
+	From            *time.Time
+	To              *time.Time
+	Tags            []string
+	MinPoints       *int
+	MaxPoints       *int
+
+	Sort   string // created_at | updated_at | submitted_at
+	Order  string // asc | desc
+	Limit  int
+	Cursor string
+
+	// RefIDs hasil full-text search Mongo; nil = tanpa batasan
+	RefIDs []string
+}
+
+type PageMeta struct {
+	Total      int     `json:"total" example:"125"`
+	Limit      int     `json:"limit" example:"20"`
+	NextCursor *string `json:"nextCursor" example:"eyJ2IjoiMjAyNS0wMS0wMVQwMDowMDowMFoiLCJpZCI6Ii4uLiJ9"`
+}
+
+// AchievementListItem reference + ringkasan detail Mongo + identitas mahasiswa
+type AchievementListItem struct {
+	Achievement
+	MongoAchievementID string   `json:"-"`
+	StudentName        string   `json:"student_name" example:"Budi Santoso"`
+	StudentNIM         string   `json:"student_nim" example:"434231081"`
+	Title              string   `json:"title" example:"Juara 1 Lomba UI/UX"`
+	AchievementType    string   `json:"achievement_type" example:"competition"`
+	Points             int      `json:"points" example:"50"`
+	Tags               []string `json:"tags"`
+}
+
+type AchievementPage struct {
+	Data []AchievementListItem `json:"data"`
+	Meta PageMeta              `json:"meta"`
+}
+
+// AchievementSearchResult item hasil full-text search
+type AchievementSearchResult struct {
+	AchievementListItem
+	Score      float64           `json:"score" example:"11.5"`
+	Highlights map[string]string `json:"highlights"`
+}
@@ -2,7 +2,32 @@ package model
 
 import "time"
 
+// jenis entri timeline history
+const (
+	HistoryTransition = "transition"
+	HistoryComment    = "comment"
+)
+
+// AchievementHistory satu entri timeline: perubahan status, atau komentar
+// (Action "comment", Status = status prestasi saat komentar ditulis, isi di Comment).
 type AchievementHistory struct {
-	Status string    `json:"status"`
-	At     time.Time `json:"at"`
+	Type       string    `json:"type" example:"transition"`
+	ID         string    `json:"id"`
+	Action     string    `json:"action"`
+	FromStatus *string   `json:"fromStatus"`
+	Status     string    `json:"status"`
+	ActorID    *string   `json:"actorId"`
+	ActorName  *string   `json:"actorName"`
+	Note       *string   `json:"note,omitempty"`
+	At         time.Time `json:"at"`
+
+	// diisi untuk override admin dan review oleh dosen pengganti (delegasi)
+	Justification  *string `json:"justification,omitempty"`
+	OnBehalfOfID   *string `json:"onBehalfOfId,omitempty"`
+	OnBehalfOfName *string `json:"onBehalfOfName,omitempty"`
+
+	// tahap rantai persetujuan (advisor, faculty, ...); kosong untuk prestasi tanpa tahap tambahan
+	Stage *string `json:"stage,omitempty"`
+
+	Comment *AchievementComment `json:"comment,omitempty"`
 }
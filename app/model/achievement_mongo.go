@@ -1,6 +1,7 @@
 package model
 
 import (
+	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -17,13 +18,46 @@ type AchievementMongo struct {
 	Attachments      []Attachment           `bson:"attachments" json:"attachments"`
 	Tags             []string               `bson:"tags" json:"tags"`
 	Points           int                    `bson:"points" json:"points"`
+	IsDeleted        bool                   `bson:"isDeleted,omitempty" json:"isDeleted,omitempty"`
 	CreatedAt        time.Time              `bson:"createdAt" json:"createdAt"`
 	UpdatedAt        time.Time              `bson:"updatedAt" json:"updatedAt"`
 }
 
+// Attachment yang disimpan hanya Key; FileURL (endpoint download ber-JWT) diisi saat dibaca.
+// Dokumen lama masih menyimpan fileUrl "/uploads/<key>" dan belum punya id.
 type Attachment struct {
+	ID         string    `bson:"id,omitempty" json:"id"`
 	FileName   string    `bson:"fileName" json:"fileName"`
-	FileURL    string    `bson:"fileUrl" json:"fileUrl"`
+	Key        string    `bson:"key,omitempty" json:"-"`
+	FileURL    string    `bson:"fileUrl,omitempty" json:"fileUrl"`
 	FileType   string    `bson:"fileType" json:"fileType"`
+	ScanStatus string    `bson:"scanStatus,omitempty" json:"scanStatus,omitempty"`
 	UploadedAt time.Time `bson:"uploadedAt" json:"uploadedAt"`
+
+	// thumbnail JPEG disimpan di samping file asli (hanya lampiran gambar)
+	ThumbnailKey string `bson:"thumbnailKey,omitempty" json:"-"`
+	ThumbnailURL string `bson:"-" json:"thumbnailUrl,omitempty"`
+}
+
+// status scan lampiran; lampiran lama (sebelum scanner) tidak punya scanStatus.
+// File terinfeksi tidak pernah jadi lampiran: dipindah ke quarantine/ dan upload ditolak.
+const (
+	ScanStatusClean     = "clean"
+	ScanStatusUnscanned = "unscanned" // scanner tidak aktif (SCANNER_DRIVER=none)
+)
+
+// StorageKey key storage, termasuk untuk dokumen lama yang hanya punya fileUrl lokal.
+func (a Attachment) StorageKey() string {
+	if a.Key != "" {
+		return a.Key
+	}
+	return strings.TrimPrefix(a.FileURL, "/uploads/")
+}
+
+// AttachmentID id lampiran; dokumen lama memakai fileName (sudah unik karena prefix uuid).
+func (a Attachment) AttachmentID() string {
+	if a.ID != "" {
+		return a.ID
+	}
+	return a.FileName
 }
@@ -0,0 +1,37 @@
+package model
+
+import "time"
+
+// status SLA antrean verifikasi, dihitung dari lama menunggu sejak submit
+const (
+	SLAOnTrack = "on_track"
+	SLAWarning = "warning"
+	SLAOverdue = "overdue"
+)
+
+// QueueItem prestasi submitted yang menunggu verifikasi Dosen Wali
+type QueueItem struct {
+	AchievementListItem
+	AttachmentCount int       `json:"attachment_count" example:"2"`
+	WaitingHours    int       `json:"waiting_hours" example:"52"`
+	SLADueAt        time.Time `json:"sla_due_at"`
+	SLAStatus       string    `json:"sla_status" example:"warning"`
+	// nama dosen wali asli jika item masuk antrean lewat delegasi
+	DelegatedFrom *string `json:"delegated_from,omitempty" example:"Dr. Sari"`
+}
+
+// QueueMeta Warning/Overdue dihitung dari seluruh antrean, bukan hanya halaman ini
+type QueueMeta struct {
+	Total    int `json:"total" example:"12"`
+	Limit    int `json:"limit" example:"50"`
+	Offset   int `json:"offset" example:"0"`
+	Warning  int `json:"warning" example:"3"`
+	Overdue  int `json:"overdue" example:"1"`
+	SLADays  int `json:"sla_days" example:"7"`
+	WarnDays int `json:"warning_days" example:"5"`
+}
+
+type AchievementQueue struct {
+	Data []QueueItem `json:"data"`
+	Meta QueueMeta   `json:"meta"`
+}
@@ -0,0 +1,25 @@
+package model
+
+import "time"
+
+// tipe event SSE (field "event" di stream)
+const (
+	StreamEventStatus  = "status"
+	StreamEventComment = "comment"
+)
+
+// StreamEvent satu event di /api/v1/events; ID dipakai sebagai id SSE / Last-Event-ID
+type StreamEvent struct {
+	ID            int64  `json:"id" example:"1024"`
+	Type          string `json:"type" example:"status"`
+	AchievementID string `json:"achievementId" example:"6ba7b810-9dad-11d1-80b4-00c04fd430c8"`
+	StudentID     string `json:"studentId" example:"550e8400-e29b-41d4-a716-446655440000"`
+	// hanya type status
+	Action     string `json:"action,omitempty" example:"verify"`
+	FromStatus string `json:"fromStatus,omitempty" example:"submitted"`
+	Status     string `json:"status,omitempty" example:"verified"`
+	// hanya type comment
+	CommentID string    `json:"commentId,omitempty" example:"7c9e6679-7425-40de-944b-e07fc1f90ae7"`
+	ActorID   string    `json:"actorId,omitempty"`
+	At        time.Time `json:"at"`
+}
@@ -0,0 +1,135 @@
+package model
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// AchievementType entry katalog tipe prestasi (Postgres, dikelola admin).
+// Schema menunjuk skema details di AchievementDetailSchemas.
+type AchievementType struct {
+	Code        string      `json:"code" example:"competition"`
+	Name        string      `json:"name" example:"Kompetisi"`
+	Schema      string      `json:"schema" example:"competition"`
+	Description *string     `json:"description"`
+	Levels      []string    `json:"levels" example:"international,national,regional,local"`
+	IsActive    bool        `json:"is_active" example:"true"`
+	PointRules  []PointRule `json:"point_rules"`
+	CreatedAt   time.Time   `json:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+}
+
+// PointRule poin untuk kombinasi level/rank. Level "" = semua level, Rank 0 = semua rank.
+type PointRule struct {
+	Level  string `json:"level" example:"national"`
+	Rank   int    `json:"rank" example:"1"`
+	Points int    `json:"points" example:"70"`
+}
+
+// HasLevel true jika level terdaftar untuk tipe ini.
+func (t *AchievementType) HasLevel(level string) bool {
+	for _, l := range t.Levels {
+		if l == level {
+			return true
+		}
+	}
+	return false
+}
+
+// CalculatePoints pilih aturan paling spesifik: level+rank > level > rank > default.
+// Tanpa aturan yang cocok = 0.
+func (t *AchievementType) CalculatePoints(level string, rank int) int {
+	best, bestScore := 0, -1
+	for _, r := range t.PointRules {
+		if r.Level != "" && r.Level != level {
+			continue
+		}
+		if r.Rank != 0 && r.Rank != rank {
+			continue
+		}
+		score := 0
+		if r.Level != "" {
+			score += 2
+		}
+		if r.Rank != 0 {
+			score++
+		}
+		if score > bestScore {
+			best, bestScore = r.Points, score
+		}
+	}
+	return best
+}
+
+// PointsForDetails hitung poin dari details (level & rank dibaca langsung dari map,
+// jadi tetap jalan untuk dokumen lama yang belum lewat validasi skema).
+func (t *AchievementType) PointsForDetails(details map[string]interface{}) int {
+	level, _ := details["level"].(string)
+	rank := 0
+	switch v := details["rank"].(type) {
+	case int:
+		rank = v
+	case int32:
+		rank = int(v)
+	case int64:
+		rank = int(v)
+	case float64:
+		rank = int(v)
+	}
+	return t.CalculatePoints(level, rank)
+}
+
+var typeCodePattern = regexp.MustCompile(`^[a-z0-9_]{2,50}$`)
+
+// Validate validasi payload katalog dari admin.
+func (t *AchievementType) Validate() []FieldError {
+	var errs []FieldError
+	add := func(field, msg string) { errs = append(errs, FieldError{Field: field, Message: msg}) }
+
+	if !typeCodePattern.MatchString(t.Code) {
+		add("code", "must be 2-50 chars of a-z, 0-9, _")
+	}
+	if strings.TrimSpace(t.Name) == "" {
+		add("name", "is required")
+	}
+	if _, ok := AchievementDetailSchemas[t.Schema]; !ok {
+		add("schema", "unknown schema")
+	}
+
+	seenLevel := map[string]bool{}
+	for _, l := range t.Levels {
+		if !typeCodePattern.MatchString(l) {
+			add("levels", "level must be 2-50 chars of a-z, 0-9, _")
+			break
+		}
+		if seenLevel[l] {
+			add("levels", "duplicate level "+l)
+			break
+		}
+		seenLevel[l] = true
+	}
+
+	type ruleKey struct {
+		level string
+		rank  int
+	}
+	seenRule := map[ruleKey]bool{}
+	for _, r := range t.PointRules {
+		switch {
+		case r.Points < 0:
+			add("point_rules", "points must be >= 0")
+		case r.Rank < 0:
+			add("point_rules", "rank must be >= 0")
+		case r.Level != "" && !seenLevel[r.Level]:
+			add("point_rules", "unknown level "+r.Level)
+		case seenRule[ruleKey{r.Level, r.Rank}]:
+			add("point_rules", "duplicate rule for level/rank")
+		default:
+			seenRule[ruleKey{r.Level, r.Rank}] = true
+			continue
+		}
+		break
+	}
+	return errs
+}
@@ -0,0 +1,245 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ===== Status =====
+
+const (
+	StatusDraft     = "draft"
+	StatusSubmitted = "submitted"
+	StatusVerified  = "verified"
+	StatusRejected  = "rejected"
+	StatusRevision  = "revision"
+	StatusDeleted   = "deleted"
+
+	// sudah diverifikasi dosen wali, menunggu tahap persetujuan berikutnya (lihat approval_stages)
+	StatusAwaitingApproval = "awaiting_approval"
+)
+
+// ===== Actions =====
+
+const (
+	ActionCreate = "create"
+	ActionEdit   = "edit" // update detail / upload attachment, status tidak berubah
+	ActionSubmit = "submit"
+	ActionRevise = "revise"
+	ActionVerify = "verify"
+	ActionReject = "reject"
+	ActionDelete = "delete"
+
+	// admin memutuskan atas nama dosen wali (wajib justification, tercatat terpisah di history)
+	ActionOverrideVerify = "override_verify"
+	ActionOverrideReject = "override_reject"
+
+	// rantai persetujuan: tiap tahap disetujui / ditolak oleh pemegang permission tahap tersebut
+	ActionApproveStage = "approve_stage"
+	ActionRejectStage  = "reject_stage"
+
+	// varian internal, dipilih server sesuai jumlah tahap tersisa (tidak muncul di allowedActions)
+	ActionVerifyStaged         = "verify_staged"
+	ActionOverrideVerifyStaged = "override_verify_staged"
+	ActionApproveFinalStage    = "approve_final_stage"
+)
+
+// ===== Roles =====
+
+const (
+	RoleAdmin    = "Admin"
+	RoleStudent  = "Mahasiswa"
+	RoleLecturer = "Dosen Wali"
+
+	RoleFacultyReviewer = "Reviewer Fakultas"
+)
+
+var (
+	ErrIllegalTransition = errors.New("illegal transition")
+	ErrRoleNotAllowed    = errors.New("role not allowed")
+)
+
+// TransitionContext data tambahan yang dibutuhkan guard.
+type TransitionContext struct {
+	Note          string
+	Justification string
+}
+
+// Transition satu baris tabel state machine: Action dari From menuju To.
+// To kosong berarti status tidak berubah (self-loop, mis. edit).
+type Transition struct {
+	Action string
+	From   string
+	To     string
+	Roles  []string
+	// Event nama yang dicatat di history, default = Action.
+	Event string
+	Guard func(TransitionContext) error
+	// Internal tidak ditampilkan di allowedActions (varian action yang dipilih server)
+	Internal bool
+	// StagePermission role tidak dicek di tabel: yang berhak = pemegang permission tahap persetujuan
+	// yang sedang berjalan (dicek repository), role apa pun. Tidak ikut AllowedActions per role.
+	StagePermission bool
+}
+
+func (t Transition) Target() string {
+	if t.To == "" {
+		return t.From
+	}
+	return t.To
+}
+
+func (t Transition) EventName() string {
+	if t.Event != "" {
+		return t.Event
+	}
+	return t.Action
+}
+
+func (t Transition) allows(role string) bool {
+	if t.StagePermission {
+		return true
+	}
+	for _, r := range t.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// TransitionError dikembalikan saat action tidak sah untuk status saat ini.
+type TransitionError struct {
+	Action         string
+	From           string
+	AllowedActions []string
+	Err            error
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("%s: cannot %s from %s", e.Err, e.Action, e.From)
+}
+
+func (e *TransitionError) Unwrap() error { return e.Err }
+
+// AchievementStateMachine state machine deklaratif untuk status prestasi.
+type AchievementStateMachine struct {
+	transitions []Transition
+}
+
+func NewAchievementStateMachine(transitions ...Transition) *AchievementStateMachine {
+	return &AchievementStateMachine{transitions: transitions}
+}
+
+// Lookup mencari transisi untuk (action, from) tanpa cek role/guard.
+func (m *AchievementStateMachine) Lookup(action, from string) (Transition, error) {
+	for _, t := range m.transitions {
+		if t.Action == action && t.From == from {
+			return t, nil
+		}
+	}
+	return Transition{}, &TransitionError{Action: action, From: from, Err: ErrIllegalTransition}
+}
+
+// Fire memvalidasi transisi lengkap (state, role, guard) dan mengembalikan transisinya.
+func (m *AchievementStateMachine) Fire(action, from, role string, ctx TransitionContext) (Transition, error) {
+	t, err := m.Lookup(action, from)
+	if err != nil {
+		return Transition{}, &TransitionError{
+			Action:         action,
+			From:           from,
+			AllowedActions: m.AllowedActions(from, role),
+			Err:            ErrIllegalTransition,
+		}
+	}
+	if !t.allows(role) {
+		return Transition{}, &TransitionError{
+			Action:         action,
+			From:           from,
+			AllowedActions: m.AllowedActions(from, role),
+			Err:            ErrRoleNotAllowed,
+		}
+	}
+	if t.Guard != nil {
+		if err := t.Guard(ctx); err != nil {
+			return Transition{}, err
+		}
+	}
+	return t, nil
+}
+
+// AllowedActions daftar action yang boleh dilakukan role dari status tertentu.
+func (m *AchievementStateMachine) AllowedActions(from, role string) []string {
+	seen := map[string]bool{}
+	out := []string{}
+	for _, t := range m.transitions {
+		if t.From == from && !t.Internal && !t.StagePermission && t.allows(role) && !seen[t.Action] {
+			seen[t.Action] = true
+			out = append(out, t.Action)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// SourceStates status asal yang valid untuk suatu action (dipakai di WHERE SQL).
+func (m *AchievementStateMachine) SourceStates(action string) []string {
+	out := []string{}
+	for _, t := range m.transitions {
+		if t.Action == action {
+			out = append(out, t.From)
+		}
+	}
+	return out
+}
+
+func requireNote(ctx TransitionContext) error {
+	if strings.TrimSpace(ctx.Note) == "" {
+		return errors.New("note is required")
+	}
+	return nil
+}
+
+func requireJustification(ctx TransitionContext) error {
+	if strings.TrimSpace(ctx.Justification) == "" {
+		return errors.New("justification is required")
+	}
+	return nil
+}
+
+func requireNoteAndJustification(ctx TransitionContext) error {
+	if err := requireNote(ctx); err != nil {
+		return err
+	}
+	return requireJustification(ctx)
+}
+
+// AchievementWorkflow tabel transisi status prestasi yang berlaku.
+var AchievementWorkflow = NewAchievementStateMachine(
+	Transition{Action: ActionCreate, From: "", To: StatusDraft, Roles: []string{RoleStudent}},
+
+	Transition{Action: ActionEdit, From: StatusDraft, Roles: []string{RoleStudent}},
+	Transition{Action: ActionEdit, From: StatusRevision, Roles: []string{RoleStudent}},
+
+	Transition{Action: ActionSubmit, From: StatusDraft, To: StatusSubmitted, Roles: []string{RoleStudent}},
+	Transition{Action: ActionSubmit, From: StatusRevision, To: StatusSubmitted, Roles: []string{RoleStudent}, Event: "resubmit"},
+
+	Transition{Action: ActionDelete, From: StatusDraft, To: StatusDeleted, Roles: []string{RoleStudent}},
+
+	Transition{Action: ActionVerify, From: StatusSubmitted, To: StatusVerified, Roles: []string{RoleLecturer}},
+	Transition{Action: ActionReject, From: StatusSubmitted, To: StatusRejected, Roles: []string{RoleLecturer}, Guard: requireNote},
+
+	Transition{Action: ActionOverrideVerify, From: StatusSubmitted, To: StatusVerified, Roles: []string{RoleAdmin}, Guard: requireJustification},
+	Transition{Action: ActionOverrideReject, From: StatusSubmitted, To: StatusRejected, Roles: []string{RoleAdmin}, Guard: requireNoteAndJustification},
+
+	Transition{Action: ActionVerifyStaged, From: StatusSubmitted, To: StatusAwaitingApproval, Roles: []string{RoleLecturer}, Event: ActionVerify, Internal: true},
+	Transition{Action: ActionOverrideVerifyStaged, From: StatusSubmitted, To: StatusAwaitingApproval, Roles: []string{RoleAdmin}, Event: ActionOverrideVerify, Guard: requireJustification, Internal: true},
+
+	Transition{Action: ActionApproveStage, From: StatusAwaitingApproval, StagePermission: true},
+	Transition{Action: ActionApproveFinalStage, From: StatusAwaitingApproval, To: StatusVerified, StagePermission: true, Event: ActionApproveStage, Internal: true},
+	Transition{Action: ActionRejectStage, From: StatusAwaitingApproval, To: StatusRejected, StagePermission: true, Guard: requireNote},
+
+	Transition{Action: ActionRevise, From: StatusRejected, To: StatusRevision, Roles: []string{RoleStudent}},
+)
@@ -0,0 +1,138 @@
+package model
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// status baris achievement_approvals
+const (
+	ApprovalPending   = "pending"
+	ApprovalApproved  = "approved"
+	ApprovalRejected  = "rejected"
+	ApprovalCancelled = "cancelled" // tahap berikutnya setelah ada yang menolak
+)
+
+// StageAdvisor nama tahap dosen wali di history (tahap pertama, tidak ada di approval_stages)
+const StageAdvisor = "advisor"
+
+// ApprovalStage aturan tahap persetujuan setelah dosen wali (Postgres, dikelola admin).
+// TypeCodes / Levels kosong = berlaku untuk semua tipe / level.
+type ApprovalStage struct {
+	Code       string    `json:"code" example:"faculty"`
+	Name       string    `json:"name" example:"Persetujuan Fakultas"`
+	Permission string    `json:"permission" example:"achievement:approve_faculty"`
+	Position   int       `json:"position" example:"1"`
+	TypeCodes  []string  `json:"type_codes" example:"competition"`
+	Levels     []string  `json:"levels" example:"national,international"`
+	IsActive   bool      `json:"is_active" example:"true"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// Matches true jika tahap berlaku untuk prestasi dengan tipe dan level ini.
+func (s *ApprovalStage) Matches(typeCode, level string) bool {
+	return s.IsActive && matchAny(s.TypeCodes, typeCode) && matchAny(s.Levels, level)
+}
+
+func matchAny(list []string, v string) bool {
+	if len(list) == 0 {
+		return true
+	}
+	for _, x := range list {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+// ApplicableStages tahap yang berlaku untuk prestasi, urut position.
+func ApplicableStages(stages []ApprovalStage, typeCode string, details map[string]interface{}) []ApprovalStage {
+	level, _ := details["level"].(string)
+	out := []ApprovalStage{}
+	for _, s := range stages {
+		if s.Matches(typeCode, level) {
+			out = append(out, s)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Position < out[j].Position })
+	return out
+}
+
+// Validate validasi payload tahap dari admin.
+func (s *ApprovalStage) Validate() []FieldError {
+	var errs []FieldError
+	add := func(field, msg string) { errs = append(errs, FieldError{Field: field, Message: msg}) }
+
+	if !typeCodePattern.MatchString(s.Code) {
+		add("code", "must be 2-50 chars of a-z, 0-9, _")
+	}
+	if s.Code == StageAdvisor {
+		add("code", "is reserved")
+	}
+	if strings.TrimSpace(s.Name) == "" {
+		add("name", "is required")
+	}
+	if !strings.Contains(s.Permission, ":") {
+		add("permission", "must be a permission name (resource:action)")
+	}
+	if s.Position <= 0 {
+		add("position", "must be greater than 0")
+	}
+	return errs
+}
+
+// AchievementApproval status satu tahap untuk satu prestasi (putaran revisi saat ini).
+type AchievementApproval struct {
+	StageCode     string     `json:"stage_code" example:"faculty"`
+	StageName     string     `json:"stage_name" example:"Persetujuan Fakultas"`
+	Permission    string     `json:"permission" example:"achievement:approve_faculty"`
+	Position      int        `json:"position" example:"1"`
+	Status        string     `json:"status" example:"pending"`
+	DecidedByID   *string    `json:"decided_by_id,omitempty"`
+	DecidedByName *string    `json:"decided_by_name,omitempty"`
+	DecidedAt     *time.Time `json:"decided_at,omitempty"`
+	Note          *string    `json:"note,omitempty"`
+}
+
+// StageActions action tahap (approve_stage / reject_stage) untuk user dengan permissions:
+// hanya jika tahap pending paling awal (approvals urut position) memakai salah satu permission itu.
+func StageActions(approvals []AchievementApproval, permissions []string) []string {
+	for _, a := range approvals {
+		if a.Status != ApprovalPending {
+			continue
+		}
+		if containsString(permissions, a.Permission) {
+			return []string{ActionApproveStage, ActionRejectStage}
+		}
+		return nil
+	}
+	return nil
+}
+
+// ApprovalQueueItem prestasi yang menunggu di tahap yang boleh diputuskan user
+type ApprovalQueueItem struct {
+	AchievementListItem
+	StageCode    string    `json:"stage_code" example:"faculty"`
+	StageName    string    `json:"stage_name" example:"Persetujuan Fakultas"`
+	Position     int       `json:"position" example:"1"`
+	TotalStages  int       `json:"total_stages" example:"1"`
+	WaitingSince time.Time `json:"waiting_since"`
+}
+
+type ApprovalDecisionRequest struct {
+	Note string `json:"note" example:"Sertifikat sesuai, disetujui fakultas"`
+}
+
+type ApprovalQueueMeta struct {
+	Total  int `json:"total" example:"4"`
+	Limit  int `json:"limit" example:"50"`
+	Offset int `json:"offset" example:"0"`
+}
+
+type ApprovalQueueResponse struct {
+	Data []ApprovalQueueItem `json:"data"`
+	Meta ApprovalQueueMeta   `json:"meta"`
+}
@@ -60,6 +60,24 @@ type LogoutResponseData struct {
 	Message string `json:"message" example:"logout success"`
 }
 
+type ForgotPasswordRequest struct {
+	Identifier string `json:"identifier" example:"user@example.com"` // username atau email
+}
+
+type ResetPasswordRequest struct {
+	Token       string `json:"token" example:"9f2c...e41a"`
+	NewPassword string `json:"newPassword" example:"rahasia-baru"`
+}
+
+type PasswordResetResponse struct {
+	Status string                    `json:"status" example:"success"`
+	Data   PasswordResetResponseData `json:"data"`
+}
+
+type PasswordResetResponseData struct {
+	Message string `json:"message" example:"password has been reset"`
+}
+
 // ===== Internal helper (DB result) =====
 
 type AuthUserInfo struct {
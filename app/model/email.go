@@ -0,0 +1,24 @@
+package model
+
+// EmailRecipient alamat dan preferensi user penerima email
+type EmailRecipient struct {
+	UserID      string
+	Email       string
+	Name        string
+	Preferences NotificationPreferences
+}
+
+// EmailDelivery satu baris email_outbox yang sudah dirender
+type EmailDelivery struct {
+	ID             string
+	UserID         string
+	NotificationID string
+	ToEmail        string
+	ToName         string
+	Template       string
+	Language       string
+	Subject        string
+	TextBody       string
+	HTMLBody       string
+	Attempts       int
+}
@@ -0,0 +1,173 @@
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+// event alur prestasi yang dipublikasikan AchievementService ke EventBus
+const (
+	EventAchievementSubmitted = "achievement.submitted"
+	// dosen wali (atau tahap sebelumnya) menyetujui, prestasi menunggu tahap persetujuan berikutnya
+	EventAchievementAwaitingApproval = "achievement.awaiting_approval"
+	EventAchievementVerified         = "achievement.verified"
+	EventAchievementRejected         = "achievement.rejected"
+	EventAchievementCommented        = "achievement.commented"
+)
+
+// AchievementEvent dipublikasikan setelah perubahan tersimpan (commit)
+type AchievementEvent struct {
+	Type          string
+	AchievementID string
+	ActorUserID   string
+	Status        string
+	// catatan penolakan / isi komentar / nama tahap persetujuan berjalan
+	Note      string
+	CommentID string
+	At        time.Time
+}
+
+// NotificationEventForTransition event notifikasi untuk transisi status; "" = tanpa notifikasi
+// (created / deleted hanya untuk webhook, revise kembali ke draft tidak diberitahukan).
+func NotificationEventForTransition(from, to string) string {
+	switch evt := WebhookEventForTransition(from, to); evt {
+	case EventAchievementCreated, EventAchievementDeleted:
+		return ""
+	default:
+		return evt
+	}
+}
+
+// NotificationEvent baris notification_events yang diklaim worker
+type NotificationEvent struct {
+	ID       string
+	Event    AchievementEvent
+	Attempts int
+}
+
+// Notification notifikasi in-app milik satu user
+type Notification struct {
+	ID            string     `json:"id" example:"7c9e6679-7425-40de-944b-e07fc1f90ae7"`
+	UserID        string     `json:"userId" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Type          string     `json:"type" example:"achievement.verified"`
+	Title         string     `json:"title" example:"Achievement verified"`
+	Message       string     `json:"message" example:"Your achievement has been verified by your advisor."`
+	AchievementID *string    `json:"achievementId,omitempty" example:"6ba7b810-9dad-11d1-80b4-00c04fd430c8"`
+	ActorID       *string    `json:"actorId,omitempty"`
+	ActorName     *string    `json:"actorName,omitempty" example:"Dr. Sari"`
+	Read          bool       `json:"read" example:"false"`
+	ReadAt        *time.Time `json:"readAt,omitempty"`
+	CreatedAt     time.Time  `json:"createdAt"`
+
+	// Note catatan mentah dari event untuk channel (mis. template email), tidak disimpan
+	Note string `json:"-"`
+	// ForReviewer penerima adalah reviewer tahap persetujuan (bukan mahasiswa pemilik)
+	ForReviewer bool `json:"-"`
+}
+
+// NotificationRecipients pihak yang terkait satu prestasi
+type NotificationRecipients struct {
+	StudentUserID string
+	// dosen wali + dosen pengganti yang delegasinya aktif hari ini
+	AdvisorUserIDs []string
+	// tahap persetujuan yang sedang berjalan (kosong jika tidak ada) dan user aktif pemegang permission-nya
+	CurrentStage    string
+	ReviewerUserIDs []string
+}
+
+type NotificationMeta struct {
+	Total  int `json:"total" example:"25"`
+	Unread int `json:"unread" example:"3"`
+	Limit  int `json:"limit" example:"20"`
+	Offset int `json:"offset" example:"0"`
+}
+
+type NotificationListResponse struct {
+	Data []Notification   `json:"data"`
+	Meta NotificationMeta `json:"meta"`
+}
+
+//
+// ===== PREFERENCES =====
+//
+
+// EmailEventTypes event yang punya template email (komentar hanya in-app)
+var EmailEventTypes = []string{EventAchievementSubmitted, EventAchievementAwaitingApproval, EventAchievementVerified, EventAchievementRejected}
+
+// NotificationLanguages bahasa email yang tersedia
+var NotificationLanguages = []string{"id", "en"}
+
+// NotificationPreferences pengaturan notifikasi milik user; user tanpa baris = default (email aktif, bahasa id)
+type NotificationPreferences struct {
+	EmailEnabled bool `json:"emailEnabled" example:"true"`
+	// tipe event yang tidak dikirim lewat email
+	EmailOptOut []string   `json:"emailOptOut" example:"achievement.submitted"`
+	Language    string     `json:"language" example:"id"`
+	UpdatedAt   *time.Time `json:"updatedAt,omitempty"`
+}
+
+func DefaultNotificationPreferences() NotificationPreferences {
+	return NotificationPreferences{EmailEnabled: true, EmailOptOut: []string{}, Language: "id"}
+}
+
+// EmailAllowed false jika user mematikan email seluruhnya atau untuk tipe event ini
+func (p NotificationPreferences) EmailAllowed(eventType string) bool {
+	if !p.EmailEnabled {
+		return false
+	}
+	for _, t := range p.EmailOptOut {
+		if t == eventType {
+			return false
+		}
+	}
+	return true
+}
+
+type NotificationPreferencesRequest struct {
+	EmailEnabled bool     `json:"emailEnabled" example:"true"`
+	EmailOptOut  []string `json:"emailOptOut" example:"achievement.submitted"`
+	Language     string   `json:"language" example:"en"`
+}
+
+func (r *NotificationPreferencesRequest) Validate() []FieldError {
+	var errs []FieldError
+	r.Language = strings.ToLower(strings.TrimSpace(r.Language))
+	if r.Language != "" && !containsString(NotificationLanguages, r.Language) {
+		errs = append(errs, FieldError{Field: "language", Message: "must be one of: " + strings.Join(NotificationLanguages, ", ")})
+	}
+	for _, t := range r.EmailOptOut {
+		if !containsString(EmailEventTypes, t) {
+			errs = append(errs, FieldError{Field: "emailOptOut", Message: "must contain only: " + strings.Join(EmailEventTypes, ", ")})
+			break
+		}
+	}
+	return errs
+}
+
+// Preferences hasil request yang sudah valid; bahasa kosong = id, opt-out tanpa duplikat
+func (r *NotificationPreferencesRequest) Preferences() NotificationPreferences {
+	p := DefaultNotificationPreferences()
+	p.EmailEnabled = r.EmailEnabled
+	if r.Language != "" {
+		p.Language = r.Language
+	}
+	for _, t := range r.EmailOptOut {
+		if !containsString(p.EmailOptOut, t) {
+			p.EmailOptOut = append(p.EmailOptOut, t)
+		}
+	}
+	return p
+}
+
+func containsString(list []string, v string) bool {
+	for _, s := range list {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
+type NotificationPreferencesResponse struct {
+	Data NotificationPreferences `json:"data"`
+}
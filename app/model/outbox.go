@@ -0,0 +1,19 @@
+package model
+
+// Operasi outbox yang diterapkan ke MongoDB
+const (
+	OutboxCreateDetail = "create_detail"
+	OutboxMarkDeleted  = "mark_deleted"
+	OutboxSetPoints    = "set_points"
+	OutboxUpdateDetail = "update_detail"
+)
+
+type OutboxEntry struct {
+	ID                 string
+	AchievementRefID   string
+	MongoAchievementID string
+	Operation          string
+	// Extended JSON dokumen Mongo (create_detail) / field yang di-$set (update_detail) / {"points": n} (set_points)
+	Payload  []byte
+	Attempts int
+}
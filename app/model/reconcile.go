@@ -0,0 +1,40 @@
+package model
+
+import "time"
+
+// OrphanReference: baris achievement_references yang dokumen Mongo-nya tidak ada
+type OrphanReference struct {
+	AchievementRefID   string `json:"achievementRefId"`
+	MongoAchievementID string `json:"mongoAchievementId"`
+	Status             string `json:"status"`
+	Repaired           bool   `json:"repaired"`
+	Note               string `json:"note,omitempty"`
+}
+
+// OrphanDocument: dokumen Mongo yang tidak direferensikan achievement_references
+type OrphanDocument struct {
+	MongoAchievementID string    `json:"mongoAchievementId"`
+	AchievementRefID   string    `json:"achievementRefId"`
+	CreatedAt          time.Time `json:"createdAt"`
+	Repaired           bool      `json:"repaired"`
+	Note               string    `json:"note,omitempty"`
+}
+
+// OrphanFile: file di storage yang tidak dirujuk lampiran mana pun
+type OrphanFile struct {
+	Key          string    `json:"key"`
+	Size         int64     `json:"size"`
+	LastModified time.Time `json:"lastModified"`
+	Repaired     bool      `json:"repaired"`
+	Note         string    `json:"note,omitempty"`
+}
+
+type ReconcileReport struct {
+	CheckedReferences int               `json:"checkedReferences"`
+	CheckedDocuments  int               `json:"checkedDocuments"`
+	CheckedFiles      int               `json:"checkedFiles"`
+	OrphanReferences  []OrphanReference `json:"orphanReferences"`
+	OrphanDocuments   []OrphanDocument  `json:"orphanDocuments"`
+	OrphanFiles       []OrphanFile      `json:"orphanFiles"`
+	Repair            bool              `json:"repair"`
+}
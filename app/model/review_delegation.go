@@ -0,0 +1,69 @@
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+// ReviewDelegation review pending mahasiswa bimbingan LecturerID boleh dikerjakan
+// SubstituteLecturerID selama StartsOn..EndsOn (tanggal, inklusif).
+type ReviewDelegation struct {
+	ID                   string     `json:"id" example:"7c9e6679-7425-40de-944b-e07fc1f90ae7"`
+	LecturerID           string     `json:"lecturerId" example:"550e8400-e29b-41d4-a716-446655440000"`
+	LecturerName         string     `json:"lecturerName" example:"Dr. Sari"`
+	SubstituteLecturerID string     `json:"substituteLecturerId" example:"6ba7b810-9dad-11d1-80b4-00c04fd430c8"`
+	SubstituteName       string     `json:"substituteName" example:"Dr. Andi"`
+	StartsOn             string     `json:"startsOn" example:"2025-03-01"`
+	EndsOn               string     `json:"endsOn" example:"2025-03-31"`
+	Reason               *string    `json:"reason,omitempty" example:"Cuti"`
+	CreatedBy            *string    `json:"createdBy,omitempty"`
+	CreatedAt            time.Time  `json:"createdAt"`
+	RevokedAt            *time.Time `json:"revokedAt,omitempty"`
+	Active               bool       `json:"active" example:"true"`
+}
+
+// ReviewDelegationRequest payload POST /lecturers/:id/delegations
+type ReviewDelegationRequest struct {
+	SubstituteLecturerID string `json:"substituteLecturerId" example:"6ba7b810-9dad-11d1-80b4-00c04fd430c8"`
+	StartsOn             string `json:"startsOn" example:"2025-03-01"`
+	EndsOn               string `json:"endsOn" example:"2025-03-31"`
+	Reason               string `json:"reason" example:"Cuti"`
+}
+
+func (r *ReviewDelegationRequest) Validate(lecturerID string) []FieldError {
+	var errs []FieldError
+	add := func(field, msg string) { errs = append(errs, FieldError{Field: field, Message: msg}) }
+
+	r.SubstituteLecturerID = strings.TrimSpace(r.SubstituteLecturerID)
+	r.Reason = strings.TrimSpace(r.Reason)
+
+	if r.SubstituteLecturerID == "" {
+		add("substituteLecturerId", "is required")
+	} else if r.SubstituteLecturerID == lecturerID {
+		add("substituteLecturerId", "must be a different lecturer")
+	}
+
+	starts, errStart := time.Parse("2006-01-02", r.StartsOn)
+	if errStart != nil {
+		add("startsOn", "must be a date (YYYY-MM-DD)")
+	}
+	ends, errEnd := time.Parse("2006-01-02", r.EndsOn)
+	if errEnd != nil {
+		add("endsOn", "must be a date (YYYY-MM-DD)")
+	}
+	if errStart == nil && errEnd == nil && ends.Before(starts) {
+		add("endsOn", "must not be before startsOn")
+	}
+	return errs
+}
+
+// OverrideVerifyRequest payload POST /achievements/:id/override/verify
+type OverrideVerifyRequest struct {
+	Justification string `json:"justification" example:"Dosen wali cuti, batas pelaporan hari ini"`
+}
+
+// OverrideRejectRequest payload POST /achievements/:id/override/reject
+type OverrideRejectRequest struct {
+	Note          string `json:"note" example:"Bukti kurang jelas"`
+	Justification string `json:"justification" example:"Dosen wali cuti, batas pelaporan hari ini"`
+}
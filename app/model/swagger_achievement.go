@@ -11,16 +11,29 @@ type MessageResponse struct {
 	Message string `json:"message" example:"success"`
 }
 
+// TransitionErrorResponse 409 saat action tidak sah untuk status saat ini
+type TransitionErrorResponse struct {
+	Message        string   `json:"message" example:"illegal transition: cannot submit from verified"`
+	CurrentStatus  string   `json:"currentStatus" example:"verified"`
+	AllowedActions []string `json:"allowedActions" example:"[]"`
+}
+
+// ValidationErrorResponse 422 saat payload tidak lolos validasi
+type ValidationErrorResponse struct {
+	Message string       `json:"message" example:"validation failed"`
+	Errors  []FieldError `json:"errors"`
+}
+
 // ===== Requests (Achievements) =====
 
 // AchievementUpsertRequest payload create/update achievement
+// details mengikuti skema tipe di katalog /achievement-types; points dihitung server dari aturan poin
 type AchievementUpsertRequest struct {
 	AchievementType string                 `json:"achievementType" example:"competition"`
 	Title           string                 `json:"title" example:"Juara 1 Lomba UI/UX"`
 	Description     string                 `json:"description" example:"Menang lomba tingkat nasional"`
 	Details         map[string]interface{} `json:"details"`
 	Tags            []string               `json:"tags" example:"[\"UI\",\"UX\"]"`
-	Points          int                    `json:"points" example:"50"`
 }
 
 // AchievementRejectRequest payload reject
@@ -32,21 +45,31 @@ type AchievementRejectRequest struct {
 
 type AchievementCreateResponse struct {
 	Data struct {
-		ID     string          `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
-		Status string          `json:"status" example:"draft"`
+		ID     string            `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+		Status string            `json:"status" example:"draft"`
 		Detail *AchievementMongo `json:"detail"`
 	} `json:"data"`
 }
 
 type AchievementListResponse struct {
-	Data []Achievement `json:"data"`
+	Data []AchievementListItem `json:"data"`
+	Meta PageMeta              `json:"meta"`
+}
+
+type AchievementSearchResponse struct {
+	Data []AchievementSearchResult `json:"data"`
 }
 
 type AchievementDetailResponse struct {
 	Data struct {
-		ID     string           `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
-		Status string           `json:"status" example:"draft"`
-		Detail *AchievementMongo `json:"detail"`
+		ID             string            `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+		Status         string            `json:"status" example:"revision"`
+		AllowedActions []string          `json:"allowedActions" example:"edit,submit"`
+		RejectionNote  *string           `json:"rejectionNote" example:"Bukti kurang jelas"`
+		RevisionCount  int               `json:"revisionCount" example:"1"`
+		Detail         *AchievementMongo `json:"detail"`
+		// tahap persetujuan putaran revisi saat ini (kosong jika tidak ada)
+		Approvals []AchievementApproval `json:"approvals"`
 	} `json:"data"`
 }
 
@@ -54,9 +77,31 @@ type AchievementHistoryResponse struct {
 	Data struct {
 		CurrentStatus string               `json:"currentStatus" example:"submitted"`
 		History       []AchievementHistory `json:"history"`
+		// status tiap tahap persetujuan putaran revisi saat ini
+		Approvals []AchievementApproval `json:"approvals"`
 	} `json:"data"`
 }
 
+type AchievementCommentListResponse struct {
+	Data []AchievementComment `json:"data"`
+}
+
+type AchievementCommentResponse struct {
+	Data AchievementComment `json:"data"`
+}
+
 type AttachmentResponse struct {
 	Data Attachment `json:"data"`
 }
+
+type UnsupportedMediaTypeResponse struct {
+	Message      string   `json:"message" example:"unsupported file type: video/mp4"`
+	AllowedTypes []string `json:"allowedTypes" example:"application/pdf,image/png,image/jpeg,image/webp"`
+}
+
+type AttachmentSignedURLResponse struct {
+	Data struct {
+		URL       string `json:"url" example:"/api/v1/achievements/550e8400-e29b-41d4-a716-446655440000/attachments/7f1c.../signed?expires=1767225600&signature=ab12..."`
+		ExpiresAt string `json:"expiresAt" example:"2026-01-01T00:05:00Z"`
+	} `json:"data"`
+}
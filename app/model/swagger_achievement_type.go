@@ -0,0 +1,21 @@
+package model
+
+// ===== Achievement Types =====
+
+type AchievementTypeListResponse struct {
+	Data []AchievementType `json:"data"`
+}
+
+type AchievementTypeDetailResponse struct {
+	Data AchievementType `json:"data"`
+}
+
+type AchievementTypeUpsertRequest struct {
+	Code        string      `json:"code" example:"hackathon"`
+	Name        string      `json:"name" example:"Hackathon"`
+	Schema      string      `json:"schema" example:"competition"`
+	Description *string     `json:"description,omitempty" example:"Lomba pengembangan produk 24-48 jam"`
+	Levels      []string    `json:"levels" example:"national,international"`
+	IsActive    *bool       `json:"is_active,omitempty" example:"true"`
+	PointRules  []PointRule `json:"point_rules"`
+}
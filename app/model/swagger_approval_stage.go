@@ -0,0 +1,21 @@
+package model
+
+// ===== Approval Stages =====
+
+type ApprovalStageListResponse struct {
+	Data []ApprovalStage `json:"data"`
+}
+
+type ApprovalStageDetailResponse struct {
+	Data ApprovalStage `json:"data"`
+}
+
+type ApprovalStageUpsertRequest struct {
+	Code       string   `json:"code" example:"faculty"`
+	Name       string   `json:"name" example:"Persetujuan Fakultas"`
+	Permission string   `json:"permission" example:"achievement:approve_faculty"`
+	Position   int      `json:"position" example:"1"`
+	TypeCodes  []string `json:"type_codes" example:"competition"`
+	Levels     []string `json:"levels" example:"national,international"`
+	IsActive   *bool    `json:"is_active,omitempty" example:"true"`
+}
@@ -10,3 +10,11 @@ type LecturerListResponse struct {
 type AdviseeListResponse struct {
 	Data []Student `json:"data"`
 }
+
+type ReviewDelegationResponse struct {
+	Data ReviewDelegation `json:"data"`
+}
+
+type ReviewDelegationListResponse struct {
+	Data []ReviewDelegation `json:"data"`
+}
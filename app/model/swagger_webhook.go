@@ -0,0 +1,21 @@
+package model
+
+// ===== Webhooks =====
+
+type WebhookListResponse struct {
+	Data []WebhookSubscription `json:"data"`
+}
+
+type WebhookDetailResponse struct {
+	Data WebhookSubscription `json:"data"`
+}
+
+type WebhookDeliveryDetailResponse struct {
+	Data WebhookDelivery `json:"data"`
+}
+
+type WebhookRedeliverResponse struct {
+	Data struct {
+		DeliveryID string `json:"delivery_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	} `json:"data"`
+}
@@ -0,0 +1,36 @@
+package model
+
+import "time"
+
+// alasan pencabutan family refresh token (refresh_token_families.revoke_reason)
+const (
+	TokenRevokeLogout        = "logout"
+	TokenRevokeLogoutAll     = "logout_all"
+	TokenRevokeReuse         = "reuse"
+	TokenRevokeDeactivated   = "deactivated"
+	TokenRevokePasswordReset = "password_reset"
+)
+
+// jenis TokenRevocation
+const (
+	TokenRevocationAccess = "access" // ID = jti access token, At = exp token
+	TokenRevocationFamily = "family" // ID = family id, At = waktu dicabut
+	TokenRevocationUser   = "user"   // ID = user id, At = batas iat (token sebelum ini ditolak)
+)
+
+// TokenRevocation satu pencabutan; juga payload NOTIFY token_revocations antar replika
+type TokenRevocation struct {
+	Kind string    `json:"kind"`
+	ID   string    `json:"id"`
+	At   time.Time `json:"at"`
+}
+
+// RefreshTokenRecord refresh token yang diterbitkan (baris refresh_tokens)
+type RefreshTokenRecord struct {
+	JTI       string
+	FamilyID  string
+	UserID    string
+	ParentJTI string
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+}
@@ -0,0 +1,191 @@
+package model
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// event prestasi tambahan yang hanya dipakai webhook (notifikasi in-app tidak dikirim)
+const (
+	EventAchievementCreated = "achievement.created"
+	EventAchievementDeleted = "achievement.deleted"
+)
+
+// WebhookEvents event yang bisa dilanggan webhook
+var WebhookEvents = []string{
+	EventAchievementCreated,
+	EventAchievementSubmitted,
+	EventAchievementAwaitingApproval,
+	EventAchievementVerified,
+	EventAchievementRejected,
+	EventAchievementDeleted,
+}
+
+// WebhookEventForTransition event webhook untuk transisi status; "" = tidak dikirim
+// (mis. revise kembali ke draft). approve_stage yang belum terakhir tetap awaiting_approval.
+func WebhookEventForTransition(from, to string) string {
+	switch to {
+	case StatusDraft:
+		if from == "" {
+			return EventAchievementCreated
+		}
+	case StatusSubmitted:
+		return EventAchievementSubmitted
+	case StatusAwaitingApproval:
+		return EventAchievementAwaitingApproval
+	case StatusVerified:
+		return EventAchievementVerified
+	case StatusRejected:
+		return EventAchievementRejected
+	case StatusDeleted:
+		return EventAchievementDeleted
+	}
+	return ""
+}
+
+// status webhook_deliveries
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliveryDelivered = "delivered"
+	WebhookDeliveryFailed    = "failed" // habis percobaan, bisa di-redeliver manual
+)
+
+// WebhookSubscription langganan webhook. Secret hanya dikembalikan saat dibuat / dirotasi.
+type WebhookSubscription struct {
+	ID     string `json:"id" example:"7c9e6679-7425-40de-944b-e07fc1f90ae7"`
+	Name   string `json:"name" example:"SIAKAD"`
+	URL    string `json:"url" example:"https://siakad.example.ac.id/hooks/prestasi"`
+	Secret string `json:"secret,omitempty" example:"9f86d081884c7d659a2feaa0c55ad015"`
+	// kosong = semua event
+	Events    []string  `json:"events" example:"achievement.verified"`
+	IsActive  bool      `json:"is_active" example:"true"`
+	CreatedBy *string   `json:"created_by,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// WebhookSubscriptionRequest body create / update. Secret kosong: dibuat otomatis (create) / tetap (update).
+type WebhookSubscriptionRequest struct {
+	Name     string   `json:"name" example:"SIAKAD"`
+	URL      string   `json:"url" example:"https://siakad.example.ac.id/hooks/prestasi"`
+	Secret   string   `json:"secret,omitempty" example:"9f86d081884c7d659a2feaa0c55ad015"`
+	Events   []string `json:"events" example:"achievement.verified"`
+	IsActive *bool    `json:"is_active,omitempty" example:"true"`
+}
+
+const webhookSecretMinLength = 16
+
+func (r *WebhookSubscriptionRequest) Validate() []FieldError {
+	var errs []FieldError
+	add := func(field, msg string) { errs = append(errs, FieldError{Field: field, Message: msg}) }
+
+	r.Name = strings.TrimSpace(r.Name)
+	r.URL = strings.TrimSpace(r.URL)
+	r.Secret = strings.TrimSpace(r.Secret)
+
+	if r.Name == "" {
+		add("name", "is required")
+	}
+	if u, err := url.Parse(r.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		add("url", "must be an absolute http(s) URL")
+	}
+	if r.Secret != "" && len(r.Secret) < webhookSecretMinLength {
+		add("secret", "must be at least 16 characters")
+	}
+	for _, e := range r.Events {
+		if !containsString(WebhookEvents, e) {
+			add("events", "must contain only: "+strings.Join(WebhookEvents, ", "))
+			break
+		}
+	}
+	return errs
+}
+
+// WebhookDelivery satu pengiriman event ke satu langganan
+type WebhookDelivery struct {
+	ID             string     `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	SubscriptionID string     `json:"subscription_id" example:"7c9e6679-7425-40de-944b-e07fc1f90ae7"`
+	EventID        string     `json:"event_id" example:"6ba7b810-9dad-11d1-80b4-00c04fd430c8"`
+	EventType      string     `json:"event_type" example:"achievement.verified"`
+	AchievementID  *string    `json:"achievement_id,omitempty"`
+	Status         string     `json:"status" example:"delivered"`
+	Attempts       int        `json:"attempts" example:"1"`
+	LastStatusCode *int       `json:"last_status_code,omitempty" example:"200"`
+	LastError      *string    `json:"last_error,omitempty"`
+	NextAttemptAt  *time.Time `json:"next_attempt_at,omitempty"`
+	RedeliveryOf   *string    `json:"redelivery_of,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+
+	// hanya di detail delivery
+	Payload json.RawMessage          `json:"payload,omitempty" swaggertype:"object"`
+	Log     []WebhookDeliveryAttempt `json:"attempt_log,omitempty"`
+}
+
+// WebhookDeliveryAttempt satu percobaan kirim (delivery log)
+type WebhookDeliveryAttempt struct {
+	Attempt      int       `json:"attempt" example:"1"`
+	StatusCode   *int      `json:"status_code,omitempty" example:"503"`
+	Error        *string   `json:"error,omitempty" example:"unexpected status 503"`
+	ResponseBody *string   `json:"response_body,omitempty"`
+	DurationMs   int       `json:"duration_ms" example:"120"`
+	AttemptedAt  time.Time `json:"attempted_at"`
+}
+
+// WebhookDispatch delivery yang diklaim worker (payload + tujuan)
+type WebhookDispatch struct {
+	ID        string
+	EventID   string
+	EventType string
+	URL       string
+	Secret    string
+	Payload   []byte
+	Attempts  int
+	// false = detail Mongo belum ditempel ke payload (lihat WebhookRepository.AttachDetail)
+	DetailAttached bool
+}
+
+// WebhookPayload body JSON yang dikirim (ditandatangani HMAC-SHA256)
+type WebhookPayload struct {
+	// ID event; sama untuk semua langganan dan saat redeliver (untuk deduplikasi penerima)
+	ID         string             `json:"id"`
+	Event      string             `json:"event"`
+	OccurredAt time.Time          `json:"occurred_at"`
+	ActorID    string             `json:"actor_id,omitempty"`
+	Data       WebhookPayloadData `json:"data"`
+}
+
+type WebhookPayloadData struct {
+	Reference *AchievementReference `json:"reference"`
+	// detail Mongo saat kirim pertama (setelah outbox event diterapkan); null jika dokumen tidak ada
+	Detail *AchievementMongo `json:"detail"`
+}
+
+// AchievementReference baris achievement_references
+type AchievementReference struct {
+	ID                 string     `json:"id"`
+	StudentID          string     `json:"student_id"`
+	MongoAchievementID string     `json:"mongo_achievement_id"`
+	Status             string     `json:"status"`
+	SubmittedAt        *time.Time `json:"submitted_at"`
+	VerifiedAt         *time.Time `json:"verified_at"`
+	VerifiedBy         *string    `json:"verified_by"`
+	VerifiedPoints     *int       `json:"verified_points"`
+	RejectionNote      *string    `json:"rejection_note"`
+	RevisionCount      int        `json:"revision_count"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+}
+
+type WebhookDeliveryMeta struct {
+	Total  int `json:"total" example:"42"`
+	Limit  int `json:"limit" example:"20"`
+	Offset int `json:"offset" example:"0"`
+}
+
+type WebhookDeliveryListResponse struct {
+	Data []WebhookDelivery   `json:"data"`
+	Meta WebhookDeliveryMeta `json:"meta"`
+}
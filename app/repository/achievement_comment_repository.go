@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"database/sql"
+
+	"project_uas/app/model"
+)
+
+// AchievementCommentRepository thread diskusi per prestasi. Scope akses dicek di service (resolveRef).
+type AchievementCommentRepository interface {
+	Create(refID, authorUserID, body string, attachmentID *string) (*model.AchievementComment, error)
+	// ListByAchievement urut dari yang paling lama
+	ListByAchievement(refID string) ([]model.AchievementComment, error)
+}
+
+type achievementCommentRepository struct {
+	db *sql.DB
+}
+
+func NewAchievementCommentRepository(db *sql.DB) AchievementCommentRepository {
+	return &achievementCommentRepository{db: db}
+}
+
+const commentSelect = `
+	SELECT c.id, c.author_user_id, u.full_name, r.name, c.body, c.attachment_id, c.created_at
+	FROM achievement_comments c
+	JOIN users u ON u.id = c.author_user_id
+	JOIN roles r ON r.id = u.role_id
+`
+
+func (r *achievementCommentRepository) Create(refID, authorUserID, body string, attachmentID *string) (*model.AchievementComment, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var id string
+	err = tx.QueryRow(`
+		INSERT INTO achievement_comments (achievement_ref_id, author_user_id, body, attachment_id, created_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		RETURNING id
+	`, refID, authorUserID, body, attachmentID).Scan(&id)
+	if err != nil {
+		return nil, err
+	}
+	if err := appendStreamEvent(tx, model.StreamEvent{
+		Type:          model.StreamEventComment,
+		AchievementID: refID,
+		ActorID:       authorUserID,
+		CommentID:     id,
+	}); err != nil {
+		return nil, err
+	}
+	if err := enqueueNotificationEvent(tx, model.AchievementEvent{
+		Type:          model.EventAchievementCommented,
+		AchievementID: refID,
+		ActorUserID:   authorUserID,
+		Note:          body,
+		CommentID:     id,
+	}); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return scanComment(r.db.QueryRow(commentSelect+`WHERE c.id = $1`, id))
+}
+
+func (r *achievementCommentRepository) ListByAchievement(refID string) ([]model.AchievementComment, error) {
+	rows, err := r.db.Query(commentSelect+`
+		WHERE c.achievement_ref_id = $1
+		ORDER BY c.created_at ASC, c.id ASC
+	`, refID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []model.AchievementComment{}
+	for rows.Next() {
+		cm, err := scanComment(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, *cm)
+	}
+	return out, rows.Err()
+}
+
+func scanComment(row rowScanner) (*model.AchievementComment, error) {
+	var cm model.AchievementComment
+	var attachmentID sql.NullString
+	if err := row.Scan(&cm.ID, &cm.AuthorID, &cm.AuthorName, &cm.AuthorRole, &cm.Body, &attachmentID, &cm.CreatedAt); err != nil {
+		return nil, err
+	}
+	cm.AttachmentID = nullStringPtr(attachmentID)
+	return &cm, nil
+}
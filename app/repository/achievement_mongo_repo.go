@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"strconv"
 	"time"
 
 	"project_uas/app/model"
@@ -9,14 +10,34 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type AchievementMongoRepository interface {
-	Create(a *model.AchievementMongo) (primitive.ObjectID, error)
+	// InsertIfAbsent idempotent: dokumen hanya dibuat jika _id belum ada (aman di-retry oleh outbox).
+	InsertIfAbsent(a *model.AchievementMongo) error
 	FindByID(id primitive.ObjectID) (*model.AchievementMongo, error)
+	// FindSummaries satu query $in untuk banyak dokumen (tanpa details; attachments hanya id)
+	FindSummaries(ids []primitive.ObjectID) (map[primitive.ObjectID]model.AchievementMongo, error)
+	// Update mongo.ErrNoDocuments jika dokumen tidak ada
 	Update(id primitive.ObjectID, update map[string]interface{}) error
-	AddAttachment(id primitive.ObjectID, attachment model.Attachment) error
+	// AddAttachment push lampiran jika jumlahnya masih < maxCount (atomic); false = batas tercapai
+	AddAttachment(id primitive.ObjectID, attachment model.Attachment, maxCount int) (bool, error)
+	// RemoveAttachment / ReplaceAttachment mengembalikan lampiran lama (false = tidak ditemukan)
+	RemoveAttachment(id primitive.ObjectID, attachmentID string) (*model.Attachment, bool, error)
+	ReplaceAttachment(id primitive.ObjectID, attachmentID string, attachment model.Attachment) (*model.Attachment, bool, error)
+	// BackfillAttachmentIDs beri id ke lampiran lama (id = fileName, sama dengan fallback AttachmentID)
+	BackfillAttachmentIDs() (int64, error)
+	MarkDeleted(id primitive.ObjectID) error
+
 	Delete(id primitive.ObjectID) error
+
+	// full-text search (text index achievements_text)
+	EnsureSearchIndex() error
+	Search(q string, studentIDs []string, limit, offset int) ([]model.AchievementMongo, []float64, error)
+
+	// reconcile: hanya _id, achievementRefId, createdAt, dan key lampiran (termasuk thumbnail)
+	ListAll() ([]model.AchievementMongo, error)
 }
 
 type achievementMongoRepository struct {
@@ -29,17 +50,22 @@ func NewAchievementMongoRepository(db *mongo.Database) AchievementMongoRepositor
 	}
 }
 
-func (r *achievementMongoRepository) Create(a *model.AchievementMongo) (primitive.ObjectID, error) {
-	now := time.Now()
-	a.CreatedAt = now
-	a.UpdatedAt = now
-	a.Attachments = []model.Attachment{}
-
-	res, err := r.collection.InsertOne(context.Background(), a)
-	if err != nil {
-		return primitive.NilObjectID, err
+func (r *achievementMongoRepository) InsertIfAbsent(a *model.AchievementMongo) error {
+	if a.Attachments == nil {
+		a.Attachments = []model.Attachment{}
 	}
-	return res.InsertedID.(primitive.ObjectID), nil
+
+	// _id diambil dari filter saat upsert
+	doc := *a
+	doc.ID = primitive.NilObjectID
+
+	_, err := r.collection.UpdateOne(
+		context.Background(),
+		bson.M{"_id": a.ID},
+		bson.M{"$setOnInsert": doc},
+		options.Update().SetUpsert(true),
+	)
+	return err
 }
 
 func (r *achievementMongoRepository) FindByID(id primitive.ObjectID) (*model.AchievementMongo, error) {
@@ -51,30 +77,228 @@ func (r *achievementMongoRepository) FindByID(id primitive.ObjectID) (*model.Ach
 	return &result, nil
 }
 
+func (r *achievementMongoRepository) FindSummaries(ids []primitive.ObjectID) (map[primitive.ObjectID]model.AchievementMongo, error) {
+	out := make(map[primitive.ObjectID]model.AchievementMongo, len(ids))
+	if len(ids) == 0 {
+		return out, nil
+	}
+
+	opts := options.Find().SetProjection(bson.M{
+		"achievementRefId": 1,
+		"achievementType":  1,
+		"title":            1,
+		"points":           1,
+		"tags":             1,
+		"attachments.id":   1, // cukup untuk menghitung jumlah lampiran
+	})
+	cur, err := r.collection.Find(context.Background(), bson.M{"_id": bson.M{"$in": ids}}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(context.Background())
+
+	for cur.Next(context.Background()) {
+		var doc model.AchievementMongo
+		if err := cur.Decode(&doc); err != nil {
+			return nil, err
+		}
+		out[doc.ID] = doc
+	}
+	return out, cur.Err()
+}
+
 func (r *achievementMongoRepository) Update(id primitive.ObjectID, update map[string]interface{}) error {
 	update["updatedAt"] = time.Now()
-	_, err := r.collection.UpdateOne(
+	res, err := r.collection.UpdateOne(
 		context.Background(),
 		bson.M{"_id": id},
 		bson.M{"$set": update},
 	)
-	return err
+	if err != nil {
+		return err
+	}
+	// dokumen belum ada (mis. create_detail belum diterapkan): outbox mencoba lagi
+	if res.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
 }
 
-func (r *achievementMongoRepository) AddAttachment(id primitive.ObjectID, attachment model.Attachment) error {
+func (r *achievementMongoRepository) AddAttachment(id primitive.ObjectID, attachment model.Attachment, maxCount int) (bool, error) {
 	attachment.UploadedAt = time.Now()
-	_, err := r.collection.UpdateOne(
+	filter := bson.M{"_id": id}
+	if maxCount > 0 {
+		// elemen ke-maxCount (index maxCount-1) belum ada = jumlah < maxCount
+		filter["attachments."+strconv.Itoa(maxCount-1)] = bson.M{"$exists": false}
+	}
+	res, err := r.collection.UpdateOne(
 		context.Background(),
-		bson.M{"_id": id},
+		filter,
 		bson.M{
 			"$push": bson.M{"attachments": attachment},
 			"$set":  bson.M{"updatedAt": time.Now()},
 		},
 	)
-	return err
+	if err != nil {
+		return false, err
+	}
+	return res.MatchedCount > 0, nil
+}
+
+func (r *achievementMongoRepository) RemoveAttachment(id primitive.ObjectID, attachmentID string) (*model.Attachment, bool, error) {
+	return r.updateAttachment(
+		bson.M{"_id": id, "attachments.id": attachmentID},
+		bson.M{
+			"$pull": bson.M{"attachments": bson.M{"id": attachmentID}},
+			"$set":  bson.M{"updatedAt": time.Now()},
+		},
+		attachmentID,
+	)
+}
+
+func (r *achievementMongoRepository) ReplaceAttachment(id primitive.ObjectID, attachmentID string, attachment model.Attachment) (*model.Attachment, bool, error) {
+	attachment.ID = attachmentID
+	attachment.UploadedAt = time.Now()
+	return r.updateAttachment(
+		bson.M{"_id": id, "attachments.id": attachmentID},
+		bson.M{"$set": bson.M{
+			"attachments.$": attachment,
+			"updatedAt":     time.Now(),
+		}},
+		attachmentID,
+	)
+}
+
+// updateAttachment jalankan update lalu ambil lampiran lama dari dokumen sebelum update
+func (r *achievementMongoRepository) updateAttachment(filter, update bson.M, attachmentID string) (*model.Attachment, bool, error) {
+	opts := options.FindOneAndUpdate().
+		SetReturnDocument(options.Before).
+		SetProjection(bson.M{"attachments": 1})
+
+	var before model.AchievementMongo
+	err := r.collection.FindOneAndUpdate(context.Background(), filter, update, opts).Decode(&before)
+	if err == mongo.ErrNoDocuments {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	for _, a := range before.Attachments {
+		if a.ID == attachmentID {
+			return &a, true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+func (r *achievementMongoRepository) BackfillAttachmentIDs() (int64, error) {
+	res, err := r.collection.UpdateMany(
+		context.Background(),
+		bson.M{"attachments": bson.M{"$elemMatch": bson.M{"id": bson.M{"$exists": false}}}},
+		mongo.Pipeline{
+			{{Key: "$set", Value: bson.M{
+				"attachments": bson.M{"$map": bson.M{
+					"input": "$attachments",
+					"as":    "a",
+					"in": bson.M{"$mergeObjects": bson.A{
+						"$$a",
+						bson.M{"id": bson.M{"$ifNull": bson.A{"$$a.id", "$$a.fileName"}}},
+					}},
+				}},
+			}}},
+		},
+	)
+	if err != nil {
+		return 0, err
+	}
+	return res.ModifiedCount, nil
+}
+
+func (r *achievementMongoRepository) MarkDeleted(id primitive.ObjectID) error {
+	return r.Update(id, map[string]interface{}{"isDeleted": true})
 }
 
 func (r *achievementMongoRepository) Delete(id primitive.ObjectID) error {
 	_, err := r.collection.DeleteOne(context.Background(), bson.M{"_id": id})
 	return err
 }
+
+// EnsureSearchIndex membuat text index wildcard (title, description, tags, details.*).
+// default_language "none": tanpa stemming/stopword karena konten campuran Indonesia-Inggris.
+func (r *achievementMongoRepository) EnsureSearchIndex() error {
+	_, err := r.collection.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys: bson.D{{Key: "$**", Value: "text"}},
+		Options: options.Index().
+			SetName("achievements_text").
+			SetDefaultLanguage("none").
+			SetWeights(bson.D{
+				{Key: "title", Value: 10},
+				{Key: "tags", Value: 5},
+				{Key: "description", Value: 3},
+			}),
+	})
+	return err
+}
+
+// Search $text search diurutkan berdasarkan textScore. studentIDs nil = tanpa batasan scope.
+func (r *achievementMongoRepository) Search(q string, studentIDs []string, limit, offset int) ([]model.AchievementMongo, []float64, error) {
+	filter := bson.M{
+		"$text":     bson.M{"$search": q},
+		"isDeleted": bson.M{"$ne": true},
+	}
+	if studentIDs != nil {
+		filter["studentId"] = bson.M{"$in": studentIDs}
+	}
+
+	opts := options.Find().
+		SetProjection(bson.M{"attachments": 0, "score": bson.M{"$meta": "textScore"}}).
+		SetSort(bson.D{{Key: "score", Value: bson.M{"$meta": "textScore"}}}).
+		SetLimit(int64(limit)).
+		SetSkip(int64(offset))
+
+	cur, err := r.collection.Find(context.Background(), filter, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer cur.Close(context.Background())
+
+	var docs []model.AchievementMongo
+	var scores []float64
+	for cur.Next(context.Background()) {
+		var doc model.AchievementMongo
+		if err := cur.Decode(&doc); err != nil {
+			return nil, nil, err
+		}
+		var meta struct {
+			Score float64 `bson:"score"`
+		}
+		if err := cur.Decode(&meta); err != nil {
+			return nil, nil, err
+		}
+		docs = append(docs, doc)
+		scores = append(scores, meta.Score)
+	}
+	return docs, scores, cur.Err()
+}
+
+func (r *achievementMongoRepository) ListAll() ([]model.AchievementMongo, error) {
+	opts := options.Find().SetProjection(bson.M{
+		"_id":                      1,
+		"achievementRefId":         1,
+		"createdAt":                1,
+		"attachments.key":          1,
+		"attachments.fileUrl":      1,
+		"attachments.thumbnailKey": 1,
+	})
+	cur, err := r.collection.Find(context.Background(), bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(context.Background())
+
+	var out []model.AchievementMongo
+	if err := cur.All(context.Background(), &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -1,44 +1,90 @@
 package repository
 
 import (
+	"crypto/sha256"
 	"database/sql"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"project_uas/app/model"
+
+	"github.com/lib/pq"
 )
 
 var (
 	ErrNotFoundOrForbidden = errors.New("not found or forbidden")
+	// tahap yang sedang berjalan butuh permission yang tidak dimiliki user
+	ErrApprovalStageForbidden = errors.New("not allowed to decide the current approval stage")
 )
 
 type AchievementRepository interface {
-	// list
-	GetAll() ([]model.Achievement, error)
-	GetByStudent(userID string) ([]model.Achievement, error)
-	GetBySupervisor(userID string) ([]model.Achievement, error)
+	// list (scope role + filter + cursor pagination)
+	List(scope model.AchievementScope, f model.AchievementFilter) (*model.AchievementPage, error)
+	// antrean verifikasi Dosen Wali: submitted milik mahasiswa bimbingan, paling lama menunggu dulu.
+	// meta berisi total + jumlah yang submit sebelum warnBefore / overdueBefore (seluruh antrean).
+	// Termasuk antrean dosen lain yang didelegasikan ke lecturerUserID hari ini.
+	ListQueue(lecturerUserID string, warnBefore, overdueBefore time.Time, limit, offset int) ([]model.QueueItem, model.QueueMeta, error)
 
 	// helpers
 	GetStudentIDByUserID(userID string) (string, bool, error)
+	GetAdviseeStudentIDs(lecturerUserID string) ([]string, error)
 	GetStatusByID(refID string) (string, bool, error)
+	GetReviewInfo(refID string) (rejectionNote *string, revisionCount int, err error)
 
-	// create (SRS-compliant: mongo_achievement_id wajib ada)
-	CreateDraftWithMongo(refID, studentID, mongoID string) error
+	// create (SRS-compliant: mongo_achievement_id wajib ada).
+	// detail (Extended JSON) masuk outbox di transaksi yang sama, Mongo ditulis oleh OutboxProcessor.
+	CreateDraftWithMongo(refID, studentID, mongoID, actorUserID string, summary model.AchievementSummary, detail []byte) error
 
 	// detail access
 	GetRefForDetailStudent(refID, userID string) (*string, string, bool, error)
 	GetRefForDetailSupervisor(refID, userID string) (*string, string, bool, error)
 	GetRefForDetailAdmin(refID string) (*string, string, bool, error)
+	// reviewer tahap: hanya prestasi yang tahap berjalan / sudah diputuskan memakai salah satu permissions
+	GetRefForDetailReviewer(refID string, permissions []string) (*string, string, bool, error)
 
 	// actions
 	Submit(id, userID string) error
+	StartRevision(id, userID string) error
 	CanDelete(id, userID string) (bool, error)
-	SoftDelete(id string) error
-	Verify(id, verifierUserID string) error
+	// UpdateDetail field detail (Extended JSON) ke Mongo lewat outbox; hanya pemilik, status yang bisa diedit
+	UpdateDetail(id, userID string, summary model.AchievementSummary, fields []byte) error
+	SoftDelete(id, actorUserID string) error
+	// stages kosong -> langsung verified; selain itu awaiting_approval dan tahapnya disalin ke achievement_approvals
+	Verify(id, verifierUserID string, points int, stages []model.ApprovalStage) error
 	Reject(id, note, rejecterUserID string) error
 
+	// override admin: tanpa cek dosen wali, history mencatat justification + dosen wali yang di-override
+	OverrideVerify(id, adminUserID string, points int, stages []model.ApprovalStage, justification string) error
+	OverrideReject(id, note, justification, adminUserID string) error
+
+	// bulk: satu transaksi, tiap item di SAVEPOINT sendiri. errs sejajar dengan input (nil = sukses).
+	// atomic=true: satu item gagal -> seluruh transaksi di-rollback (committed=false).
+	BulkVerify(items []model.BulkVerifyItem, verifierUserID string, atomic bool) (errs []error, committed bool, err error)
+	BulkReject(ids []string, note, rejecterUserID string, atomic bool) (errs []error, committed bool, err error)
+
+	// rantai persetujuan: memutuskan tahap pending paling awal, permission tahap harus ada di permissions.
+	// ApproveStage mengembalikan verified=true jika itu tahap terakhir.
+	ApproveStage(id, actorUserID, note string, permissions []string) (verified bool, err error)
+	RejectStage(id, actorUserID, note string, permissions []string) error
+	// tahap putaran revisi saat ini (kosong jika prestasi tidak butuh persetujuan tambahan)
+	ListApprovals(refID string) ([]model.AchievementApproval, error)
+	// antrean reviewer: prestasi yang tahap berjalannya butuh salah satu permissions, paling lama menunggu dulu
+	ListApprovalQueue(permissions []string, limit, offset int) ([]model.ApprovalQueueItem, int, error)
+
 	// history
-	GetImplicitHistory(refID string) ([]model.AchievementHistory, error)
+	GetHistory(refID string) ([]model.AchievementHistory, error)
+
+	// reconcile
+	ListMongoLinks() ([]model.AchievementRefLink, error)
+	// reference yang belum punya salinan AchievementSummary (dibuat sebelum kolomnya ada), urut id
+	ListMissingSummaries(afterID string, limit int) ([]model.AchievementRefLink, error)
+	// SetSummary hanya mengisi yang masih kosong (tidak menimpa nilai dari transaksi yang lebih baru)
+	SetSummary(id string, summary model.AchievementSummary) error
 }
 
 type achievementRepository struct {
@@ -53,55 +99,238 @@ func NewAchievementRepository(db *sql.DB) AchievementRepository {
 // ===== LIST =====
 //
 
-func (r *achievementRepository) GetAll() ([]model.Achievement, error) {
-	return r.fetch(`
-		SELECT id, student_id, status, created_at
-		FROM achievement_references
-		WHERE status != 'deleted'
-		ORDER BY created_at DESC
-	`)
+// kolom sort yang diizinkan -> ekspresi SQL (submitted_at jatuh ke created_at kalau NULL)
+var achievementSortColumns = map[string]string{
+	"created_at":   "ar.created_at",
+	"updated_at":   "ar.updated_at",
+	"submitted_at": "COALESCE(ar.submitted_at, ar.created_at)",
 }
 
-func (r *achievementRepository) GetByStudent(userID string) ([]model.Achievement, error) {
-	return r.fetch(`
-		SELECT ar.id, ar.student_id, ar.status, ar.created_at
-		FROM achievement_references ar
-		JOIN students s ON s.id = ar.student_id
-		WHERE s.user_id = $1
-		  AND ar.status != 'deleted'
-		ORDER BY ar.created_at DESC
-	`, userID)
-}
+var (
+	ErrInvalidCursor = errors.New("invalid cursor")
+	// cursor dibuat untuk sort / order / filter lain; posisinya tidak bermakna untuk query ini
+	ErrCursorMismatch = errors.New("cursor does not match the current sort, order or filters")
+)
 
-// ✅ FIX DOSEN WALI: filter by lecturers.user_id, bukan s.advisor_id = userID
-func (r *achievementRepository) GetBySupervisor(userID string) ([]model.Achievement, error) {
-	return r.fetch(`
-		SELECT ar.id, ar.student_id, ar.status, ar.created_at
-		FROM achievement_references ar
-		JOIN students s ON s.id = ar.student_id
-		JOIN lecturers l ON l.id = s.advisor_id
-		WHERE l.user_id = $1
-		  AND ar.status != 'deleted'
-		ORDER BY ar.created_at DESC
-	`, userID)
+// listCursor posisi baris terakhir + sort, order, dan hash filter saat cursor dibuat
+type listCursor struct {
+	Value  time.Time `json:"v"`
+	ID     string    `json:"id"`
+	Sort   string    `json:"s"`
+	Order  string    `json:"o"`
+	Filter string    `json:"f"`
 }
 
-func (r *achievementRepository) fetch(query string, args ...any) ([]model.Achievement, error) {
+// List daftar prestasi sesuai scope role + filter, keyset pagination (cursor opaque).
+func (r *achievementRepository) List(scope model.AchievementScope, f model.AchievementFilter) (*model.AchievementPage, error) {
+	sortExpr, ok := achievementSortColumns[f.Sort]
+	if !ok {
+		f.Sort, sortExpr = "created_at", achievementSortColumns["created_at"]
+	}
+	desc := f.Order != "asc"
+	order := "desc"
+	if !desc {
+		order = "asc"
+	}
+	filterHash := listFilterHash(f)
+	if f.Limit <= 0 || f.Limit > 100 {
+		f.Limit = 20
+	}
+
+	args := []any{model.StatusDeleted}
+	where := []string{"ar.status != $1"}
+	arg := func(v any) string {
+		args = append(args, v)
+		return "$" + strconv.Itoa(len(args))
+	}
+
+	join := `JOIN students s ON s.id = ar.student_id JOIN users u ON u.id = s.user_id`
+	switch scope.Role {
+	case model.RoleAdmin:
+	case model.RoleStudent:
+		where = append(where, "s.user_id = "+arg(scope.UserID))
+	case model.RoleLecturer:
+		join += ` JOIN lecturers l ON l.id = s.advisor_id`
+		where = append(where, "l.user_id = "+arg(scope.UserID))
+	default:
+		return nil, model.ErrRoleNotAllowed
+	}
+
+	if len(f.Status) > 0 {
+		where = append(where, "ar.status::text = ANY("+arg(pq.Array(f.Status))+")")
+	}
+	if f.Student != "" {
+		p := arg(f.Student)
+		where = append(where, "(s.id::text = "+p+" OR s.student_id = "+p+")")
+	}
+	if f.From != nil {
+		where = append(where, "ar.created_at >= "+arg(*f.From))
+	}
+	if f.To != nil {
+		where = append(where, "ar.created_at <= "+arg(*f.To))
+	}
+	if f.RefIDs != nil {
+		where = append(where, "ar.id::text = ANY("+arg(pq.Array(f.RefIDs))+")")
+	}
+	if f.AchievementType != "" {
+		where = append(where, "ar.achievement_type = "+arg(f.AchievementType))
+	}
+	if len(f.Tags) > 0 {
+		where = append(where, "ar.tags @> "+arg(pq.Array(f.Tags))+"::text[]")
+	}
+	if f.MinPoints != nil {
+		where = append(where, "ar.points >= "+arg(*f.MinPoints))
+	}
+	if f.MaxPoints != nil {
+		where = append(where, "ar.points <= "+arg(*f.MaxPoints))
+	}
+
+	from := `FROM achievement_references ar ` + join + ` WHERE ` + strings.Join(where, " AND ")
+
+	page := &model.AchievementPage{Data: []model.AchievementListItem{}, Meta: model.PageMeta{Limit: f.Limit}}
+	if err := r.db.QueryRow(`SELECT COUNT(*) `+from, args...).Scan(&page.Meta.Total); err != nil {
+		return nil, err
+	}
+
+	if f.Cursor != "" {
+		cur, err := decodeListCursor(f.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		if cur.Sort != f.Sort || cur.Order != order || cur.Filter != filterHash {
+			return nil, ErrCursorMismatch
+		}
+		cmp := "<"
+		if !desc {
+			cmp = ">"
+		}
+		from += " AND (" + sortExpr + ", ar.id) " + cmp + " (" + arg(cur.Value) + ", " + arg(cur.ID) + "::uuid)"
+	}
+
+	dir := "DESC"
+	if !desc {
+		dir = "ASC"
+	}
+	query := `
+		SELECT ar.id, ar.student_id, ar.status, ar.created_at, ar.updated_at, ar.submitted_at,
+		       ar.mongo_achievement_id, u.full_name, s.student_id, ` + sortExpr + `
+	` + from + `
+		ORDER BY ` + sortExpr + ` ` + dir + `, ar.id ` + dir + `
+		LIMIT ` + arg(f.Limit+1)
+
 	rows, err := r.db.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var list []model.Achievement
+	var lastSort time.Time
 	for rows.Next() {
-		var a model.Achievement
-		if err := rows.Scan(&a.ID, &a.StudentID, &a.Status, &a.CreatedAt); err != nil {
+		var a model.AchievementListItem
+		var submitted sql.NullTime
+		var sortValue time.Time
+		if err := rows.Scan(&a.ID, &a.StudentID, &a.Status, &a.CreatedAt, &a.UpdatedAt, &submitted,
+			&a.MongoAchievementID, &a.StudentName, &a.StudentNIM, &sortValue); err != nil {
 			return nil, err
 		}
-		list = append(list, a)
+		if submitted.Valid {
+			a.SubmittedAt = &submitted.Time
+		}
+		if len(page.Data) == f.Limit {
+			// ada baris berikutnya -> buat cursor dari baris terakhir halaman ini
+			last := page.Data[len(page.Data)-1]
+			next := encodeListCursor(listCursor{Value: lastSort, ID: last.ID, Sort: f.Sort, Order: order, Filter: filterHash})
+			page.Meta.NextCursor = &next
+			break
+		}
+		lastSort = sortValue
+		page.Data = append(page.Data, a)
+	}
+	return page, rows.Err()
+}
+
+func (r *achievementRepository) ListQueue(lecturerUserID string, warnBefore, overdueBefore time.Time, limit, offset int) ([]model.QueueItem, model.QueueMeta, error) {
+	from := `
+		FROM achievement_references ar
+		JOIN students s ON s.id = ar.student_id
+		JOIN users u ON u.id = s.user_id
+		JOIN lecturers l ON l.id = s.advisor_id
+		JOIN users lu ON lu.id = l.user_id
+		WHERE ar.status = $2
+		  AND (l.user_id = $1 OR ` + delegatedTo("$1") + `)`
+
+	meta := model.QueueMeta{Limit: limit, Offset: offset}
+	err := r.db.QueryRow(`
+		SELECT COUNT(*),
+		       COUNT(*) FILTER (WHERE COALESCE(ar.submitted_at, ar.created_at) <= $3
+		                          AND COALESCE(ar.submitted_at, ar.created_at) > $4),
+		       COUNT(*) FILTER (WHERE COALESCE(ar.submitted_at, ar.created_at) <= $4)
+	`+from, lecturerUserID, model.StatusSubmitted, warnBefore, overdueBefore).Scan(&meta.Total, &meta.Warning, &meta.Overdue)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	rows, err := r.db.Query(`
+		SELECT ar.id, ar.student_id, ar.status, ar.created_at, ar.updated_at, ar.submitted_at,
+		       ar.mongo_achievement_id, u.full_name, s.student_id,
+		       CASE WHEN l.user_id = $1 THEN NULL ELSE lu.full_name END
+	`+from+`
+		ORDER BY COALESCE(ar.submitted_at, ar.created_at) ASC, ar.id ASC
+		LIMIT $3 OFFSET $4
+	`, lecturerUserID, model.StatusSubmitted, limit, offset)
+	if err != nil {
+		return nil, meta, err
+	}
+	defer rows.Close()
+
+	items := []model.QueueItem{}
+	for rows.Next() {
+		var q model.QueueItem
+		var submitted sql.NullTime
+		var delegatedFrom sql.NullString
+		if err := rows.Scan(&q.ID, &q.StudentID, &q.Status, &q.CreatedAt, &q.UpdatedAt, &submitted,
+			&q.MongoAchievementID, &q.StudentName, &q.StudentNIM, &delegatedFrom); err != nil {
+			return nil, meta, err
+		}
+		if submitted.Valid {
+			q.SubmittedAt = &submitted.Time
+		}
+		q.DelegatedFrom = nullStringPtr(delegatedFrom)
+		items = append(items, q)
+	}
+	return items, meta, rows.Err()
+}
+
+func encodeListCursor(c listCursor) string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// listFilterHash sidik filter list (tanpa sort/order/limit/cursor); urutan nilai CSV tidak berpengaruh.
+func listFilterHash(f model.AchievementFilter) string {
+	sorted := func(v []string) []string {
+		out := append([]string(nil), v...)
+		sort.Strings(out)
+		return out
 	}
-	return list, nil
+	b, _ := json.Marshal([]any{
+		sorted(f.Status), f.AchievementType, f.Student, f.From, f.To,
+		sorted(f.Tags), f.MinPoints, f.MaxPoints, sorted(f.RefIDs),
+	})
+	sum := sha256.Sum256(b)
+	return base64.RawURLEncoding.EncodeToString(sum[:12])
+}
+
+func decodeListCursor(s string) (listCursor, error) {
+	var c listCursor
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, ErrInvalidCursor
+	}
+	if err := json.Unmarshal(b, &c); err != nil || c.ID == "" {
+		return c, ErrInvalidCursor
+	}
+	return c, nil
 }
 
 //
@@ -120,6 +349,29 @@ func (r *achievementRepository) GetStudentIDByUserID(userID string) (string, boo
 	return id, true, nil
 }
 
+func (r *achievementRepository) GetAdviseeStudentIDs(lecturerUserID string) ([]string, error) {
+	rows, err := r.db.Query(`
+		SELECT s.id
+		FROM students s
+		JOIN lecturers l ON l.id = s.advisor_id
+		WHERE l.user_id = $1
+	`, lecturerUserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
 func (r *achievementRepository) GetStatusByID(refID string) (string, bool, error) {
 	var status string
 	err := r.db.QueryRow(`SELECT status FROM achievement_references WHERE id=$1`, refID).Scan(&status)
@@ -132,18 +384,82 @@ func (r *achievementRepository) GetStatusByID(refID string) (string, bool, error
 	return status, true, nil
 }
 
+// selectReference baris reference lengkap tanpa scope role (payload webhook, dibaca di transaksi transisi)
+func selectReference(tx *sql.Tx, refID string) (*model.AchievementReference, error) {
+	var (
+		ref         model.AchievementReference
+		submittedAt sql.NullTime
+		verifiedAt  sql.NullTime
+		verifiedBy  sql.NullString
+		points      sql.NullInt64
+		note        sql.NullString
+	)
+	err := tx.QueryRow(`
+		SELECT id, student_id, mongo_achievement_id, status, submitted_at, verified_at, verified_by::text,
+		       verified_points, rejection_note, revision_count, created_at, updated_at
+		FROM achievement_references
+		WHERE id=$1
+	`, refID).Scan(&ref.ID, &ref.StudentID, &ref.MongoAchievementID, &ref.Status, &submittedAt, &verifiedAt,
+		&verifiedBy, &points, &note, &ref.RevisionCount, &ref.CreatedAt, &ref.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if submittedAt.Valid {
+		ref.SubmittedAt = &submittedAt.Time
+	}
+	if verifiedAt.Valid {
+		ref.VerifiedAt = &verifiedAt.Time
+	}
+	if points.Valid {
+		p := int(points.Int64)
+		ref.VerifiedPoints = &p
+	}
+	ref.VerifiedBy = nullStringPtr(verifiedBy)
+	ref.RejectionNote = nullStringPtr(note)
+	return &ref, nil
+}
+
+func (r *achievementRepository) GetReviewInfo(refID string) (*string, int, error) {
+	var note sql.NullString
+	var rounds int
+	err := r.db.QueryRow(`
+		SELECT rejection_note, revision_count
+		FROM achievement_references
+		WHERE id=$1
+	`, refID).Scan(&note, &rounds)
+	if err == sql.ErrNoRows {
+		return nil, 0, ErrNotFoundOrForbidden
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	return nullStringPtr(note), rounds, nil
+}
+
 //
 // ===== CREATE (SRS) =====
 //
 
-func (r *achievementRepository) CreateDraftWithMongo(refID, studentID, mongoID string) error {
-	_, err := r.db.Exec(`
-		INSERT INTO achievement_references
-			(id, student_id, mongo_achievement_id, status, created_at, updated_at)
-		VALUES
-			($1, $2, $3, 'draft', NOW(), NOW())
-	`, refID, studentID, mongoID)
-	return err
+func (r *achievementRepository) CreateDraftWithMongo(refID, studentID, mongoID, actorUserID string, summary model.AchievementSummary, detail []byte) error {
+	t, err := model.AchievementWorkflow.Lookup(model.ActionCreate, "")
+	if err != nil {
+		return err
+	}
+
+	return r.withTx(func(tx *sql.Tx) error {
+		if _, err := tx.Exec(`
+			INSERT INTO achievement_references
+				(id, student_id, mongo_achievement_id, status, achievement_type, points, tags, created_at, updated_at)
+			VALUES
+				($1, $2, $3, $4, $5, $6, $7, NOW(), NOW())
+		`, refID, studentID, mongoID, t.Target(), summary.AchievementType, summary.Points, summaryTags(summary)); err != nil {
+			return err
+		}
+		if err := enqueueOutbox(tx, refID, mongoID, model.OutboxCreateDetail, detail); err != nil {
+			return err
+		}
+		return insertHistory(tx, refID, t.EventName(), "", t.Target(), actorUserID, "", historyAudit{})
+	})
 }
 
 //
@@ -155,22 +471,48 @@ func (r *achievementRepository) GetRefForDetailStudent(refID, userID string) (*s
 		JOIN students s ON s.id = ar.student_id
 		WHERE ar.id=$1
 		  AND s.user_id=$2
-		  AND ar.status!='deleted'
-	`, refID, userID)
+		  AND ar.status!=$3
+	`, refID, userID, model.StatusDeleted)
 }
 
+// GetRefForDetailSupervisor dosen wali, atau dosen pengganti (delegasi aktif) untuk prestasi yang masih submitted.
 func (r *achievementRepository) GetRefForDetailSupervisor(refID, userID string) (*string, string, bool, error) {
 	return r.getDetail(`
 		JOIN students s ON s.id = ar.student_id
 		JOIN lecturers l ON l.id = s.advisor_id
 		WHERE ar.id=$1
-		  AND l.user_id=$2
-		  AND ar.status!='deleted'
-	`, refID, userID)
+		  AND (l.user_id=$2 OR (ar.status=$4 AND `+delegatedTo("$2")+`))
+		  AND ar.status!=$3
+	`, refID, userID, model.StatusDeleted, model.StatusSubmitted)
 }
 
 func (r *achievementRepository) GetRefForDetailAdmin(refID string) (*string, string, bool, error) {
-	return r.getDetail(`WHERE ar.id=$1 AND ar.status!='deleted'`, refID)
+	return r.getDetail(`WHERE ar.id=$1 AND ar.status!=$2`, refID, model.StatusDeleted)
+}
+
+// GetRefForDetailReviewer tahap yang belum sampai gilirannya (pending sesudah tahap berjalan) dan
+// tahap yang dibatalkan tidak memberi akses.
+func (r *achievementRepository) GetRefForDetailReviewer(refID string, permissions []string) (*string, string, bool, error) {
+	return r.getDetail(`
+		WHERE ar.id=$1
+		  AND ar.status!=$2
+		  AND EXISTS (
+			SELECT 1
+			FROM achievement_approvals aa
+			WHERE aa.achievement_ref_id = ar.id
+			  AND aa.permission = ANY($3)
+			  AND (aa.status IN ($4, $5)
+			       OR (aa.status = $6
+			           AND aa.round = ar.revision_count
+			           AND NOT EXISTS (
+			               SELECT 1
+			               FROM achievement_approvals p
+			               WHERE p.achievement_ref_id = aa.achievement_ref_id
+			                 AND p.round = aa.round
+			                 AND p.status = $6
+			                 AND p.position < aa.position)))
+		  )
+	`, refID, model.StatusDeleted, pq.Array(permissions), model.ApprovalApproved, model.ApprovalRejected, model.ApprovalPending)
 }
 
 func (r *achievementRepository) getDetail(where string, args ...any) (*string, string, bool, error) {
@@ -198,27 +540,35 @@ func (r *achievementRepository) getDetail(where string, args ...any) (*string, s
 
 //
 // ===== ACTIONS =====
+// Semua perubahan status lewat model.AchievementWorkflow: baris dikunci dengan scope
+// pemilik/dosen wali, transisi divalidasi, lalu UPDATE + history dalam satu transaksi.
 //
 
 func (r *achievementRepository) Submit(id, userID string) error {
-	res, err := r.db.Exec(`
-		UPDATE achievement_references ar
-		SET status='submitted',
-		    submitted_at=NOW(),
-		    updated_at=NOW()
-		FROM students s
-		WHERE ar.id=$1
-		  AND ar.student_id=s.id
-		  AND s.user_id=$2
-		  AND ar.status='draft'
-	`, id, userID)
-	if err != nil {
-		return err
-	}
-	if n, _ := res.RowsAffected(); n == 0 {
-		return ErrNotFoundOrForbidden
-	}
-	return nil
+	return r.withTx(func(tx *sql.Tx) error {
+		from, err := lockForStudent(tx, id, userID)
+		if err != nil {
+			return err
+		}
+
+		round := 0
+		if from == model.StatusRevision {
+			round = 1
+		}
+		return applyTransition(tx, id, model.ActionSubmit, from, userID, "",
+			`, submitted_at=NOW(), revision_count=revision_count + $4`, round)
+	})
+}
+
+// StartRevision membuka kembali prestasi yang ditolak agar bisa diedit (rejection_note tetap disimpan).
+func (r *achievementRepository) StartRevision(id, userID string) error {
+	return r.withTx(func(tx *sql.Tx) error {
+		from, err := lockForStudent(tx, id, userID)
+		if err != nil {
+			return err
+		}
+		return applyTransition(tx, id, model.ActionRevise, from, userID, "", "")
+	})
 }
 
 func (r *achievementRepository) CanDelete(id, userID string) (bool, error) {
@@ -229,113 +579,735 @@ func (r *achievementRepository) CanDelete(id, userID string) (bool, error) {
 		JOIN students s ON s.id=ar.student_id
 		WHERE ar.id=$1
 		  AND s.user_id=$2
-		  AND ar.status='draft'
-	`, id, userID).Scan(&count)
+		  AND ar.status = ANY($3)
+	`, id, userID, pq.Array(model.AchievementWorkflow.SourceStates(model.ActionDelete))).Scan(&count)
 	return count > 0, err
 }
 
-func (r *achievementRepository) SoftDelete(id string) error {
-	res, err := r.db.Exec(`
+func (r *achievementRepository) UpdateDetail(id, userID string, summary model.AchievementSummary, fields []byte) error {
+	return r.withTx(func(tx *sql.Tx) error {
+		from, err := lockForStudent(tx, id, userID)
+		if err != nil {
+			return err
+		}
+		if _, err := model.AchievementWorkflow.Lookup(model.ActionEdit, from); err != nil {
+			return err
+		}
+
+		var mongoID string
+		if err := tx.QueryRow(`
+			UPDATE achievement_references
+			SET achievement_type=$2, points=$3, tags=$4, updated_at=NOW()
+			WHERE id=$1
+			RETURNING mongo_achievement_id
+		`, id, summary.AchievementType, summary.Points, summaryTags(summary)).Scan(&mongoID); err != nil {
+			return err
+		}
+		return enqueueOutbox(tx, id, mongoID, model.OutboxUpdateDetail, fields)
+	})
+}
+
+func (r *achievementRepository) SoftDelete(id, actorUserID string) error {
+	return r.withTx(func(tx *sql.Tx) error {
+		from, err := lockStatus(tx, ``, id)
+		if err != nil {
+			return err
+		}
+		if err := applyTransition(tx, id, model.ActionDelete, from, actorUserID, "", ""); err != nil {
+			return err
+		}
+
+		var mongoID string
+		if err := tx.QueryRow(`SELECT mongo_achievement_id FROM achievement_references WHERE id=$1`, id).Scan(&mongoID); err != nil {
+			return err
+		}
+		return enqueueOutbox(tx, id, mongoID, model.OutboxMarkDeleted, nil)
+	})
+}
+
+// Verify membekukan poin di verified_points; nilai yang sama dikirim ke Mongo lewat outbox
+// (saat tahap terakhir disetujui jika ada rantai persetujuan).
+func (r *achievementRepository) Verify(id, verifierUserID string, points int, stages []model.ApprovalStage) error {
+	return r.withTx(func(tx *sql.Tx) error {
+		return verifyTx(tx, id, verifierUserID, points, stages)
+	})
+}
+
+func (r *achievementRepository) Reject(id, note, rejecterUserID string) error {
+	return r.withTx(func(tx *sql.Tx) error {
+		return rejectTx(tx, id, note, rejecterUserID)
+	})
+}
+
+func (r *achievementRepository) BulkVerify(items []model.BulkVerifyItem, verifierUserID string, atomic bool) ([]error, bool, error) {
+	return r.bulkTx(len(items), atomic, func(tx *sql.Tx, i int) error {
+		return verifyTx(tx, items[i].ID, verifierUserID, items[i].Points, items[i].Stages)
+	})
+}
+
+func (r *achievementRepository) BulkReject(ids []string, note, rejecterUserID string, atomic bool) ([]error, bool, error) {
+	return r.bulkTx(len(ids), atomic, func(tx *sql.Tx, i int) error {
+		return rejectTx(tx, ids[i], note, rejecterUserID)
+	})
+}
+
+func (r *achievementRepository) OverrideVerify(id, adminUserID string, points int, stages []model.ApprovalStage, justification string) error {
+	return r.withTx(func(tx *sql.Tx) error {
+		from, advisorUserID, err := lockForAdmin(tx, id)
+		if err != nil {
+			return err
+		}
+		audit := historyAudit{OnBehalfOf: advisorUserID, Justification: justification}
+		action := model.ActionOverrideVerify
+		if len(stages) > 0 {
+			action = model.ActionOverrideVerifyStaged
+		}
+		return verifyLocked(tx, id, action, from, adminUserID, audit, points, stages)
+	})
+}
+
+func (r *achievementRepository) OverrideReject(id, note, justification, adminUserID string) error {
+	return r.withTx(func(tx *sql.Tx) error {
+		from, advisorUserID, err := lockForAdmin(tx, id)
+		if err != nil {
+			return err
+		}
+		audit := historyAudit{OnBehalfOf: advisorUserID, Justification: justification}
+		return applyAuditedTransition(tx, id, model.ActionOverrideReject, from, adminUserID, note, audit,
+			`, rejection_note=$4`, note)
+	})
+}
+
+func verifyTx(tx *sql.Tx, id, verifierUserID string, points int, stages []model.ApprovalStage) error {
+	from, onBehalfOf, err := lockForSupervisor(tx, id, verifierUserID)
+	if err != nil {
+		return err
+	}
+	action := model.ActionVerify
+	if len(stages) > 0 {
+		action = model.ActionVerifyStaged
+	}
+	return verifyLocked(tx, id, action, from, verifierUserID, historyAudit{OnBehalfOf: onBehalfOf}, points, stages)
+}
+
+// verifyLocked transisi verify untuk baris yang sudah dikunci. Tanpa tahap tambahan langsung verified
+// dan poin dikirim ke Mongo lewat outbox; dengan tahap -> awaiting_approval, poin ditahan di verified_points
+// sampai tahap terakhir disetujui.
+func verifyLocked(tx *sql.Tx, id, action, from, actorUserID string, audit historyAudit, points int, stages []model.ApprovalStage) error {
+	if len(stages) == 0 {
+		if err := applyAuditedTransition(tx, id, action, from, actorUserID, "", audit,
+			`, verified_at=NOW(), verified_by=$4, verified_points=$5`, actorUserID, points); err != nil {
+			return err
+		}
+		return enqueueSetPoints(tx, id, points)
+	}
+
+	audit.Stage = model.StageAdvisor
+	if err := applyAuditedTransition(tx, id, action, from, actorUserID, "", audit,
+		`, verified_at=NULL, verified_by=$4, verified_points=$5`, actorUserID, points); err != nil {
+		return err
+	}
+	// position dinomori ulang 1..n agar urutan unik walau dua aturan punya position sama
+	for i, st := range stages {
+		if _, err := tx.Exec(`
+			INSERT INTO achievement_approvals
+				(achievement_ref_id, round, position, stage_code, stage_name, permission, status, created_at)
+			SELECT id, revision_count, $2, $3, $4, $5, $6, NOW()
+			FROM achievement_references
+			WHERE id=$1
+		`, id, i+1, st.Code, st.Name, st.Permission, model.ApprovalPending); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func enqueueSetPoints(tx *sql.Tx, id string, points int) error {
+	var mongoID string
+	if err := tx.QueryRow(`
 		UPDATE achievement_references
-		SET status='deleted',
-		    updated_at=NOW()
+		SET points=$2
 		WHERE id=$1
-		  AND status='draft'
-	`, id)
+		RETURNING mongo_achievement_id
+	`, id, points).Scan(&mongoID); err != nil {
+		return err
+	}
+	payload, _ := json.Marshal(map[string]int{"points": points})
+	return enqueueOutbox(tx, id, mongoID, model.OutboxSetPoints, payload)
+}
+
+func rejectTx(tx *sql.Tx, id, note, rejecterUserID string) error {
+	from, onBehalfOf, err := lockForSupervisor(tx, id, rejecterUserID)
 	if err != nil {
 		return err
 	}
-	if n, _ := res.RowsAffected(); n == 0 {
-		return ErrNotFoundOrForbidden
+	return applyAuditedTransition(tx, id, model.ActionReject, from, rejecterUserID, note, historyAudit{OnBehalfOf: onBehalfOf},
+		`, rejection_note=$4`, note)
+}
+
+// bulkTx jalankan fn untuk n item dalam satu transaksi. Item yang gagal di-rollback ke savepoint-nya
+// sehingga item lain tetap tersimpan, kecuali atomic=true.
+func (r *achievementRepository) bulkTx(n int, atomic bool, fn func(tx *sql.Tx, i int) error) ([]error, bool, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, false, err
 	}
-	return nil
+	defer tx.Rollback()
+
+	errs := make([]error, n)
+	failed := false
+	for i := 0; i < n; i++ {
+		if _, err := tx.Exec(`SAVEPOINT bulk_item`); err != nil {
+			return nil, false, err
+		}
+		if errs[i] = fn(tx, i); errs[i] != nil {
+			failed = true
+			if _, err := tx.Exec(`ROLLBACK TO SAVEPOINT bulk_item`); err != nil {
+				return nil, false, err
+			}
+			if atomic {
+				break
+			}
+			continue
+		}
+		if _, err := tx.Exec(`RELEASE SAVEPOINT bulk_item`); err != nil {
+			return nil, false, err
+		}
+	}
+
+	if failed && atomic {
+		return errs, false, nil
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, false, err
+	}
+	return errs, true, nil
 }
 
-func (r *achievementRepository) Verify(id, verifierUserID string) error {
-	res, err := r.db.Exec(`
-		UPDATE achievement_references ar
-		SET status='verified',
-		    verified_at=NOW(),
-		    verified_by=$2,
-		    updated_at=NOW()
-		FROM students s
+func lockForStudent(tx *sql.Tx, id, userID string) (string, error) {
+	return lockStatus(tx, `
+		JOIN students s ON s.id = ar.student_id
+		  AND s.user_id = $3
+	`, id, userID)
+}
+
+// lockForSupervisor kunci baris untuk dosen wali atau dosen pengganti (delegasi aktif, hanya status submitted).
+// onBehalfOf berisi user id dosen wali jika yang bertindak adalah pengganti.
+func lockForSupervisor(tx *sql.Tx, id, userID string) (status, onBehalfOf string, err error) {
+	var advisorUserID string
+	err = tx.QueryRow(`
+		SELECT ar.status, l.user_id
+		FROM achievement_references ar
+		JOIN students s ON s.id = ar.student_id
 		JOIN lecturers l ON l.id = s.advisor_id
 		WHERE ar.id = $1
-		  AND ar.student_id = s.id
-		  AND l.user_id = $2
-		  AND ar.status = 'submitted'
-	`, id, verifierUserID)
+		  AND ar.status != $2
+		  AND (l.user_id = $3 OR (ar.status = $4 AND `+delegatedTo("$3")+`))
+		FOR UPDATE OF ar
+	`, id, model.StatusDeleted, userID, model.StatusSubmitted).Scan(&status, &advisorUserID)
+	if err == sql.ErrNoRows {
+		return "", "", ErrNotFoundOrForbidden
+	}
 	if err != nil {
-		return err
+		return "", "", err
 	}
-	if n, _ := res.RowsAffected(); n == 0 {
-		return ErrNotFoundOrForbidden
+	if advisorUserID != userID {
+		onBehalfOf = advisorUserID
 	}
-	return nil
+	return status, onBehalfOf, nil
 }
 
-func (r *achievementRepository) Reject(id, note, rejecterUserID string) error {
-	res, err := r.db.Exec(`
-		UPDATE achievement_references ar
-		SET status='rejected',
-		    rejection_note=$3,
-		    updated_at=NOW()
-		FROM students s
-		JOIN lecturers l ON l.id = s.advisor_id
+// lockForAdmin kunci baris tanpa batasan scope; advisorUserID kosong jika mahasiswa belum punya dosen wali.
+func lockForAdmin(tx *sql.Tx, id string) (status, advisorUserID string, err error) {
+	var advisor sql.NullString
+	err = tx.QueryRow(`
+		SELECT ar.status, l.user_id
+		FROM achievement_references ar
+		JOIN students s ON s.id = ar.student_id
+		LEFT JOIN lecturers l ON l.id = s.advisor_id
+		WHERE ar.id = $1
+		  AND ar.status != $2
+		FOR UPDATE OF ar
+	`, id, model.StatusDeleted).Scan(&status, &advisor)
+	if err == sql.ErrNoRows {
+		return "", "", ErrNotFoundOrForbidden
+	}
+	return status, advisor.String, err
+}
+
+// delegatedTo kondisi SQL: user (param) adalah dosen pengganti aktif hari ini untuk dosen wali mahasiswa s.
+func delegatedTo(param string) string {
+	return `EXISTS (
+		SELECT 1
+		FROM review_delegations d
+		JOIN lecturers sl ON sl.id = d.substitute_lecturer_id
+		WHERE d.lecturer_id = s.advisor_id
+		  AND sl.user_id = ` + param + `
+		  AND d.revoked_at IS NULL
+		  AND CURRENT_DATE BETWEEN d.starts_on AND d.ends_on
+	)`
+}
+
+// lockStatus mengunci baris reference (FOR UPDATE) dan mengembalikan status saat ini.
+// join dipakai untuk membatasi scope; parameter join mulai dari $3.
+func lockStatus(tx *sql.Tx, join, id string, args ...any) (string, error) {
+	var status string
+	err := tx.QueryRow(`
+		SELECT ar.status
+		FROM achievement_references ar
+	`+join+`
 		WHERE ar.id = $1
-		  AND ar.student_id = s.id
-		  AND l.user_id = $2
-		  AND ar.status = 'submitted'
-	`, id, rejecterUserID, note)
+		  AND ar.status != $2
+		FOR UPDATE OF ar
+	`, append([]any{id, model.StatusDeleted}, args...)...).Scan(&status)
+	if err == sql.ErrNoRows {
+		return "", ErrNotFoundOrForbidden
+	}
+	return status, err
+}
+
+// historyAudit data tambahan history untuk override admin / review oleh dosen pengganti / tahap persetujuan
+type historyAudit struct {
+	OnBehalfOf    string
+	Justification string
+	Stage         string
+}
+
+// applyTransition menjalankan transisi (action, from) sesuai AchievementWorkflow.
+// set berisi kolom tambahan yang di-update; parameternya mulai dari $4.
+func applyTransition(tx *sql.Tx, id, action, from, actorUserID, note, set string, args ...any) error {
+	return applyAuditedTransition(tx, id, action, from, actorUserID, note, historyAudit{}, set, args...)
+}
+
+func applyAuditedTransition(tx *sql.Tx, id, action, from, actorUserID, note string, audit historyAudit, set string, args ...any) error {
+	t, err := model.AchievementWorkflow.Lookup(action, from)
 	if err != nil {
 		return err
 	}
-	if n, _ := res.RowsAffected(); n == 0 {
-		return ErrNotFoundOrForbidden
+
+	res, err := tx.Exec(`
+		UPDATE achievement_references
+		SET status=$1,
+		    updated_at=NOW()`+set+`
+		WHERE id=$2
+		  AND status=$3
+	`, append([]any{t.Target(), id, from}, args...)...)
+	if err := mustAffect(res, err); err != nil {
+		return err
 	}
-	return nil
+	return insertHistory(tx, id, t.EventName(), from, t.Target(), actorUserID, note, audit)
+}
+
+//
+// ===== APPROVAL CHAIN =====
+// Tahap setelah dosen wali diputuskan berurutan (position); hanya tahap pending paling awal
+// di putaran revisi saat ini (round = revision_count) yang bisa diputuskan.
+//
+
+func (r *achievementRepository) ApproveStage(id, actorUserID, note string, permissions []string) (bool, error) {
+	verified := false
+	err := r.withTx(func(tx *sql.Tx) error {
+		from, err := lockStatus(tx, ``, id)
+		if err != nil {
+			return err
+		}
+		if _, err := model.AchievementWorkflow.Lookup(model.ActionApproveStage, from); err != nil {
+			return err
+		}
+		approvalID, stageCode, remaining, err := lockCurrentApproval(tx, id, permissions)
+		if err != nil {
+			return err
+		}
+
+		action, set := model.ActionApproveStage, ""
+		if remaining == 0 {
+			action, set = model.ActionApproveFinalStage, `, verified_at=NOW()`
+		}
+		if err := applyAuditedTransition(tx, id, action, from, actorUserID, note, historyAudit{Stage: stageCode}, set); err != nil {
+			return err
+		}
+		if err := decideApproval(tx, approvalID, model.ApprovalApproved, actorUserID, note); err != nil {
+			return err
+		}
+		if remaining > 0 {
+			return nil
+		}
+
+		verified = true
+		var points int
+		if err := tx.QueryRow(`SELECT COALESCE(verified_points, 0) FROM achievement_references WHERE id=$1`, id).Scan(&points); err != nil {
+			return err
+		}
+		return enqueueSetPoints(tx, id, points)
+	})
+	return verified, err
+}
+
+// RejectStage menolak di tahap berjalan; tahap sesudahnya dibatalkan dan poin yang ditahan dilepas.
+func (r *achievementRepository) RejectStage(id, actorUserID, note string, permissions []string) error {
+	return r.withTx(func(tx *sql.Tx) error {
+		from, err := lockStatus(tx, ``, id)
+		if err != nil {
+			return err
+		}
+		if _, err := model.AchievementWorkflow.Lookup(model.ActionRejectStage, from); err != nil {
+			return err
+		}
+		approvalID, stageCode, _, err := lockCurrentApproval(tx, id, permissions)
+		if err != nil {
+			return err
+		}
+
+		if err := applyAuditedTransition(tx, id, model.ActionRejectStage, from, actorUserID, note, historyAudit{Stage: stageCode},
+			`, rejection_note=$4, verified_by=NULL, verified_points=NULL`, note); err != nil {
+			return err
+		}
+		if err := decideApproval(tx, approvalID, model.ApprovalRejected, actorUserID, note); err != nil {
+			return err
+		}
+		_, err = tx.Exec(`
+			UPDATE achievement_approvals
+			SET status=$2
+			WHERE achievement_ref_id=$1
+			  AND status=$3
+		`, id, model.ApprovalCancelled, model.ApprovalPending)
+		return err
+	})
+}
+
+// lockCurrentApproval kunci tahap pending paling awal di putaran saat ini dan cek permission-nya.
+// remaining = jumlah tahap pending sesudahnya.
+func lockCurrentApproval(tx *sql.Tx, refID string, permissions []string) (approvalID, stageCode string, remaining int, err error) {
+	var permission string
+	err = tx.QueryRow(`
+		SELECT aa.id, aa.stage_code, aa.permission,
+		       (SELECT COUNT(*)
+		        FROM achievement_approvals n
+		        WHERE n.achievement_ref_id = aa.achievement_ref_id
+		          AND n.round = aa.round
+		          AND n.status = $2
+		          AND n.position > aa.position)
+		FROM achievement_approvals aa
+		JOIN achievement_references ar ON ar.id = aa.achievement_ref_id AND ar.revision_count = aa.round
+		WHERE aa.achievement_ref_id = $1
+		  AND aa.status = $2
+		ORDER BY aa.position
+		LIMIT 1
+		FOR UPDATE OF aa
+	`, refID, model.ApprovalPending).Scan(&approvalID, &stageCode, &permission, &remaining)
+	if err == sql.ErrNoRows {
+		return "", "", 0, ErrNotFoundOrForbidden
+	}
+	if err != nil {
+		return "", "", 0, err
+	}
+	for _, p := range permissions {
+		if p == permission {
+			return approvalID, stageCode, remaining, nil
+		}
+	}
+	return "", "", 0, ErrApprovalStageForbidden
+}
+
+func decideApproval(tx *sql.Tx, approvalID, status, actorUserID, note string) error {
+	return mustAffect(tx.Exec(`
+		UPDATE achievement_approvals
+		SET status=$2,
+		    decided_by=$3,
+		    decided_at=NOW(),
+		    note=NULLIF($4, '')
+		WHERE id=$1
+	`, approvalID, status, actorUserID, note))
+}
+
+func (r *achievementRepository) ListApprovals(refID string) ([]model.AchievementApproval, error) {
+	rows, err := r.db.Query(`
+		SELECT aa.stage_code, aa.stage_name, aa.permission, aa.position, aa.status,
+		       aa.decided_by, u.full_name, aa.decided_at, aa.note
+		FROM achievement_approvals aa
+		JOIN achievement_references ar ON ar.id = aa.achievement_ref_id AND ar.revision_count = aa.round
+		LEFT JOIN users u ON u.id = aa.decided_by
+		WHERE aa.achievement_ref_id = $1
+		ORDER BY aa.position
+	`, refID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []model.AchievementApproval{}
+	for rows.Next() {
+		var (
+			a         model.AchievementApproval
+			decidedBy sql.NullString
+			name      sql.NullString
+			decidedAt sql.NullTime
+			note      sql.NullString
+		)
+		if err := rows.Scan(&a.StageCode, &a.StageName, &a.Permission, &a.Position, &a.Status,
+			&decidedBy, &name, &decidedAt, &note); err != nil {
+			return nil, err
+		}
+		a.DecidedByID = nullStringPtr(decidedBy)
+		a.DecidedByName = nullStringPtr(name)
+		if decidedAt.Valid {
+			a.DecidedAt = &decidedAt.Time
+		}
+		a.Note = nullStringPtr(note)
+		out = append(out, a)
+	}
+	return out, rows.Err()
+}
+
+func (r *achievementRepository) ListApprovalQueue(permissions []string, limit, offset int) ([]model.ApprovalQueueItem, int, error) {
+	// tahap pending paling awal per prestasi (tahap sebelumnya sudah approved)
+	from := `
+		FROM achievement_approvals aa
+		JOIN achievement_references ar ON ar.id = aa.achievement_ref_id AND ar.revision_count = aa.round
+		JOIN students s ON s.id = ar.student_id
+		JOIN users u ON u.id = s.user_id
+		WHERE ar.status = $1
+		  AND aa.status = $2
+		  AND aa.permission = ANY($3)
+		  AND NOT EXISTS (
+		      SELECT 1
+		      FROM achievement_approvals p
+		      WHERE p.achievement_ref_id = aa.achievement_ref_id
+		        AND p.round = aa.round
+		        AND p.status = $2
+		        AND p.position < aa.position
+		  )`
+	args := []any{model.StatusAwaitingApproval, model.ApprovalPending, pq.Array(permissions)}
+
+	var total int
+	if err := r.db.QueryRow(`SELECT COUNT(*)`+from, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := r.db.Query(`
+		SELECT ar.id, ar.student_id, ar.status, ar.created_at, ar.updated_at, ar.submitted_at,
+		       ar.mongo_achievement_id, u.full_name, s.student_id,
+		       aa.stage_code, aa.stage_name, aa.position,
+		       (SELECT COUNT(*) FROM achievement_approvals t
+		        WHERE t.achievement_ref_id = aa.achievement_ref_id AND t.round = aa.round)
+	`+from+`
+		ORDER BY ar.updated_at ASC, ar.id ASC
+		LIMIT $4 OFFSET $5
+	`, append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	items := []model.ApprovalQueueItem{}
+	for rows.Next() {
+		var q model.ApprovalQueueItem
+		var submitted sql.NullTime
+		if err := rows.Scan(&q.ID, &q.StudentID, &q.Status, &q.CreatedAt, &q.UpdatedAt, &submitted,
+			&q.MongoAchievementID, &q.StudentName, &q.StudentNIM,
+			&q.StageCode, &q.StageName, &q.Position, &q.TotalStages); err != nil {
+			return nil, 0, err
+		}
+		if submitted.Valid {
+			q.SubmittedAt = &submitted.Time
+		}
+		// updated_at berubah di setiap keputusan tahap, jadi = mulai menunggu di tahap ini
+		q.WaitingSince = q.UpdatedAt
+		items = append(items, q)
+	}
+	return items, total, rows.Err()
 }
 
 //
 // ===== HISTORY =====
 //
 
-func (r *achievementRepository) GetImplicitHistory(refID string) ([]model.AchievementHistory, error) {
-	var (
-		status      string
-		createdAt   time.Time
-		submittedAt sql.NullTime
-		verifiedAt  sql.NullTime
-	)
+func (r *achievementRepository) GetHistory(refID string) ([]model.AchievementHistory, error) {
+	rows, err := r.db.Query(`
+		SELECT h.id, h.action, h.from_status, h.to_status, h.actor_user_id, u.full_name, h.note, h.created_at,
+		       h.justification, h.on_behalf_of_user_id, ob.full_name, h.stage
+		FROM achievement_status_history h
+		LEFT JOIN users u ON u.id = h.actor_user_id
+		LEFT JOIN users ob ON ob.id = h.on_behalf_of_user_id
+		WHERE h.achievement_ref_id = $1
+		ORDER BY h.created_at ASC, h.id ASC
+	`, refID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
 
-	err := r.db.QueryRow(`
-		SELECT status, created_at, submitted_at, verified_at
+	history := []model.AchievementHistory{}
+	for rows.Next() {
+		var (
+			h         model.AchievementHistory
+			from      sql.NullString
+			actorID   sql.NullString
+			actorName sql.NullString
+			note      sql.NullString
+			justif    sql.NullString
+			obID      sql.NullString
+			obName    sql.NullString
+			stage     sql.NullString
+		)
+		if err := rows.Scan(&h.ID, &h.Action, &from, &h.Status, &actorID, &actorName, &note, &h.At,
+			&justif, &obID, &obName, &stage); err != nil {
+			return nil, err
+		}
+		h.FromStatus = nullStringPtr(from)
+		h.ActorID = nullStringPtr(actorID)
+		h.ActorName = nullStringPtr(actorName)
+		h.Note = nullStringPtr(note)
+		h.Justification = nullStringPtr(justif)
+		h.OnBehalfOfID = nullStringPtr(obID)
+		h.OnBehalfOfName = nullStringPtr(obName)
+		h.Type = model.HistoryTransition
+		h.Stage = nullStringPtr(stage)
+		history = append(history, h)
+	}
+	return history, rows.Err()
+}
+
+//
+// ===== RECONCILE =====
+//
+
+func (r *achievementRepository) ListMongoLinks() ([]model.AchievementRefLink, error) {
+	rows, err := r.db.Query(`
+		SELECT id, mongo_achievement_id, status
 		FROM achievement_references
-		WHERE id = $1
-	`, refID).Scan(&status, &createdAt, &submittedAt, &verifiedAt)
+		ORDER BY created_at
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
 
-	if err == sql.ErrNoRows {
-		return nil, ErrNotFoundOrForbidden
+	var out []model.AchievementRefLink
+	for rows.Next() {
+		var l model.AchievementRefLink
+		if err := rows.Scan(&l.RefID, &l.MongoID, &l.Status); err != nil {
+			return nil, err
+		}
+		out = append(out, l)
 	}
+	return out, rows.Err()
+}
+
+func (r *achievementRepository) ListMissingSummaries(afterID string, limit int) ([]model.AchievementRefLink, error) {
+	rows, err := r.db.Query(`
+		SELECT id, mongo_achievement_id, status
+		FROM achievement_references
+		WHERE achievement_type IS NULL
+		  AND ($1 = '' OR id > $1::uuid)
+		ORDER BY id
+		LIMIT $2
+	`, afterID, limit)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
-	history := []model.AchievementHistory{
-		{Status: "draft", At: createdAt},
+	out := []model.AchievementRefLink{}
+	for rows.Next() {
+		var l model.AchievementRefLink
+		if err := rows.Scan(&l.RefID, &l.MongoID, &l.Status); err != nil {
+			return nil, err
+		}
+		out = append(out, l)
 	}
-	if submittedAt.Valid {
-		history = append(history, model.AchievementHistory{Status: "submitted", At: submittedAt.Time})
+	return out, rows.Err()
+}
+
+func (r *achievementRepository) SetSummary(id string, summary model.AchievementSummary) error {
+	_, err := r.db.Exec(`
+		UPDATE achievement_references
+		SET achievement_type=$2, points=$3, tags=$4
+		WHERE id=$1
+		  AND achievement_type IS NULL
+	`, id, summary.AchievementType, summary.Points, summaryTags(summary))
+	return err
+}
+
+// summaryTags kolom tags NOT NULL: nil disimpan sebagai array kosong
+func summaryTags(s model.AchievementSummary) any {
+	if s.Tags == nil {
+		return pq.Array([]string{})
+	}
+	return pq.Array(s.Tags)
+}
+
+//
+// ===== TX HELPERS =====
+//
+
+func (r *achievementRepository) withTx(fn func(tx *sql.Tx) error) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
 	}
-	if status == "verified" && verifiedAt.Valid {
-		history = append(history, model.AchievementHistory{Status: "verified", At: verifiedAt.Time})
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// insertHistory mencatat satu transisi status di transaksi yang sama dengan UPDATE-nya,
+// beserta event SSE dan delivery webhook-nya.
+func insertHistory(tx *sql.Tx, refID, action, fromStatus, toStatus, actorUserID, note string, audit historyAudit) error {
+	_, err := tx.Exec(`
+		INSERT INTO achievement_status_history
+			(achievement_ref_id, action, from_status, to_status, actor_user_id, note,
+			 justification, on_behalf_of_user_id, stage, created_at)
+		VALUES
+			($1, $2, NULLIF($3, ''), $4, NULLIF($5, '')::uuid, NULLIF($6, ''),
+			 NULLIF($7, ''), NULLIF($8, '')::uuid, NULLIF($9, ''), NOW())
+	`, refID, action, fromStatus, toStatus, actorUserID, note, audit.Justification, audit.OnBehalfOf, audit.Stage)
+	if err != nil {
+		return err
+	}
+	if err := appendStreamEvent(tx, model.StreamEvent{
+		Type:          model.StreamEventStatus,
+		AchievementID: refID,
+		Action:        action,
+		FromStatus:    fromStatus,
+		Status:        toStatus,
+		ActorID:       actorUserID,
+	}); err != nil {
+		return err
 	}
-	if status == "rejected" {
-		at := createdAt
-		if submittedAt.Valid {
-			at = submittedAt.Time
+	if evt := model.NotificationEventForTransition(fromStatus, toStatus); evt != "" {
+		if err := enqueueNotificationEvent(tx, model.AchievementEvent{
+			Type:          evt,
+			AchievementID: refID,
+			ActorUserID:   actorUserID,
+			Status:        toStatus,
+			Note:          note,
+		}); err != nil {
+			return err
 		}
-		history = append(history, model.AchievementHistory{Status: "rejected", At: at})
 	}
-	return history, nil
+	if evt := model.WebhookEventForTransition(fromStatus, toStatus); evt != "" {
+		return enqueueWebhookEvent(tx, evt, refID, actorUserID)
+	}
+	return nil
+}
+
+func mustAffect(res sql.Result, err error) error {
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrNotFoundOrForbidden
+	}
+	return nil
+}
+
+func nullStringPtr(v sql.NullString) *string {
+	if !v.Valid {
+		return nil
+	}
+	return &v.String
 }
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"database/sql"
+
+	"project_uas/app/model"
+)
+
+// StreamChannel channel LISTEN/NOTIFY untuk achievement_stream_events (payload = seq).
+// NOTIFY dikirim trigger achievement_stream_publish (migrasi 017), nama channel sama.
+const StreamChannel = "achievement_stream"
+
+// AchievementStreamRepository baca event stream SSE. Event ditulis oleh insertHistory dan
+// komentar baru (appendStreamEvent) di transaksi yang sama dengan perubahannya.
+// ID event = seq, dibagikan saat commit sesuai urutan commit (aman dipakai sebagai Last-Event-ID).
+type AchievementStreamRepository interface {
+	GetByID(id int64) (*model.StreamEvent, bool, error)
+	// ListAfter event dengan seq > afterID dalam jendela replay, urut seq
+	ListAfter(afterID int64, limit int) ([]model.StreamEvent, error)
+	// Prune hapus event yang lebih lama dari jendela replay
+	Prune() (int64, error)
+}
+
+type achievementStreamRepository struct {
+	db *sql.DB
+}
+
+func NewAchievementStreamRepository(db *sql.DB) AchievementStreamRepository {
+	return &achievementStreamRepository{db: db}
+}
+
+// streamReplayWindow batas Last-Event-ID yang masih bisa di-resume
+const streamReplayWindow = `INTERVAL '24 hours'`
+
+const streamEventSelect = `
+	SELECT seq, type, achievement_ref_id, student_id, COALESCE(action, ''), COALESCE(from_status, ''),
+	       COALESCE(to_status, ''), COALESCE(actor_user_id::text, ''), COALESCE(comment_id::text, ''), created_at
+	FROM achievement_stream_events
+`
+
+func (r *achievementStreamRepository) GetByID(id int64) (*model.StreamEvent, bool, error) {
+	e, err := scanStreamEvent(r.db.QueryRow(streamEventSelect+`WHERE seq = $1`, id))
+	if err == sql.ErrNoRows {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return e, true, nil
+}
+
+func (r *achievementStreamRepository) ListAfter(afterID int64, limit int) ([]model.StreamEvent, error) {
+	rows, err := r.db.Query(streamEventSelect+`
+		WHERE seq > $1
+		  AND created_at > NOW() - `+streamReplayWindow+`
+		ORDER BY seq
+		LIMIT $2
+	`, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []model.StreamEvent{}
+	for rows.Next() {
+		e, err := scanStreamEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, *e)
+	}
+	return out, rows.Err()
+}
+
+func (r *achievementStreamRepository) Prune() (int64, error) {
+	res, err := r.db.Exec(`
+		DELETE FROM achievement_stream_events
+		WHERE created_at < NOW() - ` + streamReplayWindow)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+func scanStreamEvent(row rowScanner) (*model.StreamEvent, error) {
+	var e model.StreamEvent
+	if err := row.Scan(&e.ID, &e.Type, &e.AchievementID, &e.StudentID, &e.Action, &e.FromStatus,
+		&e.Status, &e.ActorID, &e.CommentID, &e.At); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// appendStreamEvent dipanggil di dalam transaksi perubahan. seq dan NOTIFY diisi trigger deferred
+// saat commit sehingga client tidak pernah melihat perubahan yang di-rollback.
+func appendStreamEvent(tx *sql.Tx, e model.StreamEvent) error {
+	_, err := tx.Exec(`
+		INSERT INTO achievement_stream_events
+			(type, achievement_ref_id, student_id, action, from_status, to_status, actor_user_id, comment_id, created_at)
+		SELECT $1, ar.id, ar.student_id, NULLIF($3, ''), NULLIF($4, ''), NULLIF($5, ''),
+		       NULLIF($6, '')::uuid, NULLIF($7, '')::uuid, NOW()
+		FROM achievement_references ar
+		WHERE ar.id = $2
+	`, e.Type, e.AchievementID, e.Action, e.FromStatus, e.Status, e.ActorID, e.CommentID)
+	return err
+}
@@ -0,0 +1,217 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+
+	"project_uas/app/model"
+
+	"github.com/lib/pq"
+)
+
+var (
+	ErrAchievementTypeExists = errors.New("achievement type already exists")
+)
+
+type AchievementTypeRepository interface {
+	List(includeInactive bool) ([]model.AchievementType, error)
+	GetByCode(code string) (*model.AchievementType, bool, error)
+
+	// Create/Update menulis tipe + mengganti seluruh aturan poin dalam satu transaksi
+	Create(t *model.AchievementType) error
+	Update(t *model.AchievementType) (bool, error)
+
+	// Deactivate soft delete (dokumen lama tetap menunjuk code ini)
+	Deactivate(code string) (bool, error)
+}
+
+type achievementTypeRepository struct {
+	db *sql.DB
+}
+
+func NewAchievementTypeRepository(db *sql.DB) AchievementTypeRepository {
+	return &achievementTypeRepository{db: db}
+}
+
+func (r *achievementTypeRepository) List(includeInactive bool) ([]model.AchievementType, error) {
+	rows, err := r.db.Query(`
+		SELECT code, name, schema, description, levels, is_active, created_at, updated_at
+		FROM achievement_types
+		WHERE ($1 OR is_active)
+		ORDER BY name
+	`, includeInactive)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []model.AchievementType{}
+	index := map[string]int{}
+	for rows.Next() {
+		t, err := scanAchievementType(rows)
+		if err != nil {
+			return nil, err
+		}
+		index[t.Code] = len(out)
+		out = append(out, *t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rules, err := r.db.Query(`
+		SELECT type_code, level, rank, points
+		FROM achievement_point_rules
+		ORDER BY type_code, level, rank
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rules.Close()
+
+	for rules.Next() {
+		var code string
+		var pr model.PointRule
+		if err := rules.Scan(&code, &pr.Level, &pr.Rank, &pr.Points); err != nil {
+			return nil, err
+		}
+		if i, ok := index[code]; ok {
+			out[i].PointRules = append(out[i].PointRules, pr)
+		}
+	}
+	return out, rules.Err()
+}
+
+func (r *achievementTypeRepository) GetByCode(code string) (*model.AchievementType, bool, error) {
+	t, err := scanAchievementType(r.db.QueryRow(`
+		SELECT code, name, schema, description, levels, is_active, created_at, updated_at
+		FROM achievement_types
+		WHERE code=$1
+	`, code))
+	if err == sql.ErrNoRows {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	rows, err := r.db.Query(`
+		SELECT level, rank, points
+		FROM achievement_point_rules
+		WHERE type_code=$1
+		ORDER BY level, rank
+	`, code)
+	if err != nil {
+		return nil, false, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var pr model.PointRule
+		if err := rows.Scan(&pr.Level, &pr.Rank, &pr.Points); err != nil {
+			return nil, false, err
+		}
+		t.PointRules = append(t.PointRules, pr)
+	}
+	return t, true, rows.Err()
+}
+
+func (r *achievementTypeRepository) Create(t *model.AchievementType) error {
+	return r.withTx(func(tx *sql.Tx) error {
+		res, err := tx.Exec(`
+			INSERT INTO achievement_types (code, name, schema, description, levels, is_active, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, NOW(), NOW())
+			ON CONFLICT (code) DO NOTHING
+		`, t.Code, t.Name, t.Schema, t.Description, pq.Array(t.Levels), t.IsActive)
+		if err != nil {
+			return err
+		}
+		if n, _ := res.RowsAffected(); n == 0 {
+			return ErrAchievementTypeExists
+		}
+		return replacePointRules(tx, t.Code, t.PointRules)
+	})
+}
+
+func (r *achievementTypeRepository) Update(t *model.AchievementType) (bool, error) {
+	found := false
+	err := r.withTx(func(tx *sql.Tx) error {
+		res, err := tx.Exec(`
+			UPDATE achievement_types
+			SET name=$2,
+			    schema=$3,
+			    description=$4,
+			    levels=$5,
+			    is_active=$6,
+			    updated_at=NOW()
+			WHERE code=$1
+		`, t.Code, t.Name, t.Schema, t.Description, pq.Array(t.Levels), t.IsActive)
+		if err != nil {
+			return err
+		}
+		if n, _ := res.RowsAffected(); n == 0 {
+			return nil
+		}
+		found = true
+		return replacePointRules(tx, t.Code, t.PointRules)
+	})
+	return found, err
+}
+
+func (r *achievementTypeRepository) Deactivate(code string) (bool, error) {
+	res, err := r.db.Exec(`
+		UPDATE achievement_types SET is_active=false, updated_at=NOW() WHERE code=$1
+	`, code)
+	if err != nil {
+		return false, err
+	}
+	n, _ := res.RowsAffected()
+	return n > 0, nil
+}
+
+func (r *achievementTypeRepository) withTx(fn func(tx *sql.Tx) error) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func replacePointRules(tx *sql.Tx, code string, rules []model.PointRule) error {
+	if _, err := tx.Exec(`DELETE FROM achievement_point_rules WHERE type_code=$1`, code); err != nil {
+		return err
+	}
+	for _, pr := range rules {
+		if _, err := tx.Exec(`
+			INSERT INTO achievement_point_rules (type_code, level, rank, points)
+			VALUES ($1, $2, $3, $4)
+		`, code, pr.Level, pr.Rank, pr.Points); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanAchievementType(row rowScanner) (*model.AchievementType, error) {
+	var t model.AchievementType
+	var description sql.NullString
+	var levels pq.StringArray
+	if err := row.Scan(&t.Code, &t.Name, &t.Schema, &description, &levels, &t.IsActive, &t.CreatedAt, &t.UpdatedAt); err != nil {
+		return nil, err
+	}
+	t.Description = nullStringPtr(description)
+	t.Levels = []string(levels)
+	if t.Levels == nil {
+		t.Levels = []string{}
+	}
+	return &t, nil
+}
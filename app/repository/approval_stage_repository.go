@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+
+	"project_uas/app/model"
+
+	"github.com/lib/pq"
+)
+
+var (
+	ErrApprovalStageExists = errors.New("approval stage already exists")
+)
+
+// ApprovalStageRepository aturan tahap persetujuan setelah dosen wali.
+// Perubahan aturan hanya berlaku untuk verifikasi berikutnya; prestasi yang sedang berjalan
+// memakai salinan tahap di achievement_approvals.
+type ApprovalStageRepository interface {
+	List(includeInactive bool) ([]model.ApprovalStage, error)
+	GetByCode(code string) (*model.ApprovalStage, bool, error)
+	Create(s *model.ApprovalStage) error
+	Update(s *model.ApprovalStage) (bool, error)
+	Deactivate(code string) (bool, error)
+}
+
+type approvalStageRepository struct {
+	db *sql.DB
+}
+
+func NewApprovalStageRepository(db *sql.DB) ApprovalStageRepository {
+	return &approvalStageRepository{db: db}
+}
+
+func (r *approvalStageRepository) List(includeInactive bool) ([]model.ApprovalStage, error) {
+	rows, err := r.db.Query(`
+		SELECT code, name, permission, position, type_codes, levels, is_active, created_at, updated_at
+		FROM approval_stages
+		WHERE ($1 OR is_active)
+		ORDER BY position, code
+	`, includeInactive)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []model.ApprovalStage{}
+	for rows.Next() {
+		s, err := scanApprovalStage(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, *s)
+	}
+	return out, rows.Err()
+}
+
+func (r *approvalStageRepository) GetByCode(code string) (*model.ApprovalStage, bool, error) {
+	s, err := scanApprovalStage(r.db.QueryRow(`
+		SELECT code, name, permission, position, type_codes, levels, is_active, created_at, updated_at
+		FROM approval_stages
+		WHERE code=$1
+	`, code))
+	if err == sql.ErrNoRows {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return s, true, nil
+}
+
+func (r *approvalStageRepository) Create(s *model.ApprovalStage) error {
+	res, err := r.db.Exec(`
+		INSERT INTO approval_stages (code, name, permission, position, type_codes, levels, is_active, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW(), NOW())
+		ON CONFLICT (code) DO NOTHING
+	`, s.Code, s.Name, s.Permission, s.Position, pq.Array(s.TypeCodes), pq.Array(s.Levels), s.IsActive)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrApprovalStageExists
+	}
+	return nil
+}
+
+func (r *approvalStageRepository) Update(s *model.ApprovalStage) (bool, error) {
+	res, err := r.db.Exec(`
+		UPDATE approval_stages
+		SET name=$2,
+		    permission=$3,
+		    position=$4,
+		    type_codes=$5,
+		    levels=$6,
+		    is_active=$7,
+		    updated_at=NOW()
+		WHERE code=$1
+	`, s.Code, s.Name, s.Permission, s.Position, pq.Array(s.TypeCodes), pq.Array(s.Levels), s.IsActive)
+	if err != nil {
+		return false, err
+	}
+	n, _ := res.RowsAffected()
+	return n > 0, nil
+}
+
+func (r *approvalStageRepository) Deactivate(code string) (bool, error) {
+	res, err := r.db.Exec(`
+		UPDATE approval_stages SET is_active=false, updated_at=NOW() WHERE code=$1
+	`, code)
+	if err != nil {
+		return false, err
+	}
+	n, _ := res.RowsAffected()
+	return n > 0, nil
+}
+
+func scanApprovalStage(row rowScanner) (*model.ApprovalStage, error) {
+	var s model.ApprovalStage
+	var typeCodes, levels pq.StringArray
+	if err := row.Scan(&s.Code, &s.Name, &s.Permission, &s.Position, &typeCodes, &levels,
+		&s.IsActive, &s.CreatedAt, &s.UpdatedAt); err != nil {
+		return nil, err
+	}
+	s.TypeCodes = []string(typeCodes)
+	if s.TypeCodes == nil {
+		s.TypeCodes = []string{}
+	}
+	s.Levels = []string(levels)
+	if s.Levels == nil {
+		s.Levels = []string{}
+	}
+	return &s, nil
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"database/sql"
 	"errors"
+	"time"
 
 	"project_uas/app/model"
 )
@@ -13,6 +14,12 @@ type AuthRepository interface {
 
 	// new for profile/refresh
 	GetUserByID(userID string) (*model.AuthUserInfo, bool, error)
+
+	// CreatePasswordResetToken simpan hash token reset; token lama user yang belum dipakai dibatalkan
+	CreatePasswordResetToken(userID, tokenHash string, expiresAt time.Time) error
+	// ResetPassword pakai token (sekali) dan ganti password. false = token tidak ada / kedaluwarsa /
+	// sudah dipakai / user nonaktif.
+	ResetPassword(tokenHash, passwordHash string) (string, bool, error)
 }
 
 type authRepository struct {
@@ -127,3 +134,71 @@ func (r *authRepository) GetPermissionsByRole(roleID string) ([]string, error) {
 	}
 	return perms, nil
 }
+
+// =====================
+// PASSWORD RESET
+// =====================
+
+func (r *authRepository) CreatePasswordResetToken(userID, tokenHash string, expiresAt time.Time) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`
+		UPDATE password_reset_tokens
+		SET used_at = NOW()
+		WHERE user_id = $1 AND used_at IS NULL
+	`, userID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`
+		INSERT INTO password_reset_tokens (user_id, token_hash, expires_at)
+		VALUES ($1, $2, $3)
+	`, userID, tokenHash, expiresAt); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *authRepository) ResetPassword(tokenHash, passwordHash string) (string, bool, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return "", false, err
+	}
+	defer tx.Rollback()
+
+	// kunci token: dua reset paralel dengan token yang sama, yang kedua melihat used_at terisi
+	var userID string
+	err = tx.QueryRow(`
+		SELECT t.user_id
+		FROM password_reset_tokens t
+		JOIN users u ON u.id = t.user_id
+		WHERE t.token_hash = $1
+		  AND t.used_at IS NULL
+		  AND t.expires_at > NOW()
+		  AND u.is_active = TRUE
+		FOR UPDATE OF t
+	`, tokenHash).Scan(&userID)
+	if err == sql.ErrNoRows {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+
+	if _, err := tx.Exec(`
+		UPDATE users SET password_hash = $2, updated_at = NOW() WHERE id = $1
+	`, userID, passwordHash); err != nil {
+		return "", false, err
+	}
+	if _, err := tx.Exec(`
+		UPDATE password_reset_tokens
+		SET used_at = NOW()
+		WHERE user_id = $1 AND used_at IS NULL
+	`, userID); err != nil {
+		return "", false, err
+	}
+	return userID, true, tx.Commit()
+}
@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"project_uas/app/model"
+)
+
+type EmailRepository interface {
+	// Enqueue menyimpan email yang sudah dirender ke email_outbox, mengembalikan ID
+	Enqueue(e *model.EmailDelivery) (string, error)
+	// ClaimPending mengunci email pending (id kosong = semua) dan menaikkan attempts
+	ClaimPending(id string, limit int) ([]model.EmailDelivery, error)
+	MarkSent(id string) error
+	MarkFailed(id string, cause error, retryAt time.Time) error
+	// DeadLetter menandai email dead dan mencatatnya di email_dead_letters (satu transaksi)
+	DeadLetter(id string, cause error) error
+}
+
+type emailRepository struct {
+	db *sql.DB
+}
+
+func NewEmailRepository(db *sql.DB) EmailRepository {
+	return &emailRepository{db: db}
+}
+
+func (r *emailRepository) Enqueue(e *model.EmailDelivery) (string, error) {
+	var id string
+	err := r.db.QueryRow(`
+		INSERT INTO email_outbox
+			(user_id, notification_id, to_email, to_name, template, language, subject, text_body, html_body,
+			 created_at, next_attempt_at)
+		VALUES
+			(NULLIF($1, '')::uuid, NULLIF($2, '')::uuid, $3, $4, $5, $6, $7, $8, $9, NOW(), NOW())
+		RETURNING id
+	`, e.UserID, e.NotificationID, e.ToEmail, e.ToName, e.Template, e.Language, e.Subject, e.TextBody, e.HTMLBody).Scan(&id)
+	if err != nil {
+		return "", err
+	}
+	e.ID = id
+	return id, nil
+}
+
+func (r *emailRepository) ClaimPending(id string, limit int) ([]model.EmailDelivery, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+
+	rows, err := r.db.Query(`
+		UPDATE email_outbox o
+		SET locked_until = NOW() + INTERVAL '2 minutes',
+		    attempts = o.attempts + 1
+		WHERE o.id IN (
+			SELECT id
+			FROM email_outbox
+			WHERE status = 'pending'
+			  AND next_attempt_at <= NOW()
+			  AND (locked_until IS NULL OR locked_until < NOW())
+			  AND ($1 = '' OR id::text = $1)
+			ORDER BY created_at
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING o.id, COALESCE(o.user_id::text, ''), COALESCE(o.notification_id::text, ''),
+		          o.to_email, o.to_name, o.template, o.language, o.subject, o.text_body, o.html_body, o.attempts
+	`, id, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []model.EmailDelivery
+	for rows.Next() {
+		var e model.EmailDelivery
+		if err := rows.Scan(&e.ID, &e.UserID, &e.NotificationID, &e.ToEmail, &e.ToName, &e.Template,
+			&e.Language, &e.Subject, &e.TextBody, &e.HTMLBody, &e.Attempts); err != nil {
+			return nil, err
+		}
+		out = append(out, e)
+	}
+	return out, rows.Err()
+}
+
+func (r *emailRepository) MarkSent(id string) error {
+	_, err := r.db.Exec(`
+		UPDATE email_outbox
+		SET status='sent',
+		    sent_at=NOW(),
+		    locked_until=NULL,
+		    last_error=NULL
+		WHERE id=$1
+	`, id)
+	return err
+}
+
+func (r *emailRepository) MarkFailed(id string, cause error, retryAt time.Time) error {
+	_, err := r.db.Exec(`
+		UPDATE email_outbox
+		SET last_error=$2,
+		    next_attempt_at=$3,
+		    locked_until=NULL
+		WHERE id=$1
+	`, id, cause.Error(), retryAt)
+	return err
+}
+
+func (r *emailRepository) DeadLetter(id string, cause error) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec(`
+		UPDATE email_outbox
+		SET status='dead',
+		    last_error=$2,
+		    locked_until=NULL
+		WHERE id=$1
+		  AND status='pending'
+	`, id, cause.Error())
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return nil
+	}
+
+	if _, err := tx.Exec(`
+		INSERT INTO email_dead_letters (email_id, user_id, to_email, template, subject, attempts, last_error, failed_at)
+		SELECT id, user_id, to_email, template, subject, attempts, last_error, NOW()
+		FROM email_outbox
+		WHERE id=$1
+	`, id); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,402 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"project_uas/app/model"
+
+	"github.com/lib/pq"
+)
+
+type NotificationRepository interface {
+	// CreateForEvent menyimpan notifikasi hasil satu event (ID, CreatedAt, ActorName diisi dari DB).
+	// false jika penerima sudah mendapat notifikasi event ini (event diproses ulang).
+	CreateForEvent(eventID string, n *model.Notification) (bool, error)
+	ListByUser(userID string, unreadOnly bool, limit, offset int) ([]model.Notification, model.NotificationMeta, error)
+	// MarkRead false jika notifikasi tidak ada / bukan milik user. Idempotent.
+	MarkRead(userID, id string) (bool, error)
+
+	// Recipients mahasiswa pemilik + dosen wali (dan pengganti aktif) + reviewer tahap berjalan untuk satu prestasi
+	Recipients(refID string) (model.NotificationRecipients, error)
+
+	// GetPreferences default jika user belum pernah menyimpan preferensi
+	GetPreferences(userID string) (model.NotificationPreferences, error)
+	SavePreferences(userID string, p model.NotificationPreferences) (model.NotificationPreferences, error)
+	// EmailRecipient false jika user tidak ada / nonaktif / tanpa email
+	EmailRecipient(userID string) (model.EmailRecipient, bool, error)
+
+	// ClaimEvents mengunci event notifikasi pending supaya tidak diproses replica lain.
+	ClaimEvents(limit int) ([]model.NotificationEvent, error)
+	MarkEventDone(id string) error
+	// MarkEventFailed retryAt nil = percobaan habis, status failed
+	MarkEventFailed(id string, cause error, retryAt *time.Time) error
+}
+
+type notificationRepository struct {
+	db *sql.DB
+}
+
+func NewNotificationRepository(db *sql.DB) NotificationRepository {
+	return &notificationRepository{db: db}
+}
+
+const notificationSelect = `
+	SELECT n.id, n.user_id, n.type, n.title, n.message, n.achievement_ref_id,
+	       n.actor_user_id, a.full_name, n.read_at, n.created_at
+	FROM notifications n
+	LEFT JOIN users a ON a.id = n.actor_user_id
+`
+
+func (r *notificationRepository) CreateForEvent(eventID string, n *model.Notification) (bool, error) {
+	var id string
+	err := r.db.QueryRow(`
+		INSERT INTO notifications (user_id, type, title, message, achievement_ref_id, actor_user_id, event_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, '')::uuid, $7, NOW())
+		ON CONFLICT (event_id, user_id) WHERE event_id IS NOT NULL DO NOTHING
+		RETURNING id
+	`, n.UserID, n.Type, n.Title, n.Message, n.AchievementID, stringValue(n.ActorID), eventID).Scan(&id)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	saved, err := scanNotification(r.db.QueryRow(notificationSelect+`WHERE n.id = $1`, id))
+	if err != nil {
+		return false, err
+	}
+	*n = *saved
+	return true, nil
+}
+
+func (r *notificationRepository) ListByUser(userID string, unreadOnly bool, limit, offset int) ([]model.Notification, model.NotificationMeta, error) {
+	meta := model.NotificationMeta{Limit: limit, Offset: offset}
+	err := r.db.QueryRow(`
+		SELECT COUNT(*), COUNT(*) FILTER (WHERE read_at IS NULL)
+		FROM notifications
+		WHERE user_id = $1
+	`, userID).Scan(&meta.Total, &meta.Unread)
+	if err != nil {
+		return nil, meta, err
+	}
+	if unreadOnly {
+		meta.Total = meta.Unread
+	}
+
+	rows, err := r.db.Query(notificationSelect+`
+		WHERE n.user_id = $1
+		  AND (NOT $2 OR n.read_at IS NULL)
+		ORDER BY n.created_at DESC, n.id DESC
+		LIMIT $3 OFFSET $4
+	`, userID, unreadOnly, limit, offset)
+	if err != nil {
+		return nil, meta, err
+	}
+	defer rows.Close()
+
+	out := []model.Notification{}
+	for rows.Next() {
+		n, err := scanNotification(rows)
+		if err != nil {
+			return nil, meta, err
+		}
+		out = append(out, *n)
+	}
+	return out, meta, rows.Err()
+}
+
+func (r *notificationRepository) MarkRead(userID, id string) (bool, error) {
+	res, err := r.db.Exec(`
+		UPDATE notifications
+		SET read_at = COALESCE(read_at, NOW())
+		WHERE id::text = $1
+		  AND user_id = $2
+	`, id, userID)
+	if err != nil {
+		return false, err
+	}
+	n, _ := res.RowsAffected()
+	return n > 0, nil
+}
+
+func (r *notificationRepository) Recipients(refID string) (model.NotificationRecipients, error) {
+	var out model.NotificationRecipients
+	var advisor sql.NullString
+	err := r.db.QueryRow(`
+		SELECT s.user_id, l.user_id
+		FROM achievement_references ar
+		JOIN students s ON s.id = ar.student_id
+		LEFT JOIN lecturers l ON l.id = s.advisor_id
+		WHERE ar.id = $1
+	`, refID).Scan(&out.StudentUserID, &advisor)
+	if err == sql.ErrNoRows {
+		return out, ErrNotFoundOrForbidden
+	}
+	if err != nil {
+		return out, err
+	}
+	if err := r.stageReviewers(refID, &out); err != nil {
+		return out, err
+	}
+	if !advisor.Valid {
+		return out, nil
+	}
+	out.AdvisorUserIDs = append(out.AdvisorUserIDs, advisor.String)
+
+	rows, err := r.db.Query(`
+		SELECT DISTINCT sl.user_id
+		FROM achievement_references ar
+		JOIN students s ON s.id = ar.student_id
+		JOIN review_delegations d ON d.lecturer_id = s.advisor_id
+		JOIN lecturers sl ON sl.id = d.substitute_lecturer_id
+		WHERE ar.id = $1
+		  AND d.revoked_at IS NULL
+		  AND CURRENT_DATE BETWEEN d.starts_on AND d.ends_on
+	`, refID)
+	if err != nil {
+		return out, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return out, err
+		}
+		out.AdvisorUserIDs = append(out.AdvisorUserIDs, id)
+	}
+	return out, rows.Err()
+}
+
+// stageReviewers tahap pending paling awal di putaran revisi saat ini + user aktif yang role-nya punya permission tahap itu
+func (r *notificationRepository) stageReviewers(refID string, out *model.NotificationRecipients) error {
+	var permission string
+	err := r.db.QueryRow(`
+		SELECT aa.stage_name, aa.permission
+		FROM achievement_approvals aa
+		JOIN achievement_references ar ON ar.id = aa.achievement_ref_id AND ar.revision_count = aa.round
+		WHERE aa.achievement_ref_id = $1
+		  AND aa.status = $2
+		ORDER BY aa.position
+		LIMIT 1
+	`, refID, model.ApprovalPending).Scan(&out.CurrentStage, &permission)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	rows, err := r.db.Query(`
+		SELECT DISTINCT u.id
+		FROM users u
+		JOIN role_permissions rp ON rp.role_id = u.role_id
+		JOIN permissions p ON p.id = rp.permission_id
+		WHERE p.name = $1
+		  AND u.is_active
+	`, permission)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return err
+		}
+		out.ReviewerUserIDs = append(out.ReviewerUserIDs, id)
+	}
+	return rows.Err()
+}
+
+//
+// ===== PREFERENCES =====
+//
+
+func (r *notificationRepository) GetPreferences(userID string) (model.NotificationPreferences, error) {
+	p, ok, err := scanPreferences(r.db.QueryRow(`
+		SELECT email_enabled, email_opt_out, language, updated_at
+		FROM notification_preferences
+		WHERE user_id = $1
+	`, userID))
+	if err != nil || !ok {
+		return model.DefaultNotificationPreferences(), err
+	}
+	return p, nil
+}
+
+func (r *notificationRepository) SavePreferences(userID string, p model.NotificationPreferences) (model.NotificationPreferences, error) {
+	saved, _, err := scanPreferences(r.db.QueryRow(`
+		INSERT INTO notification_preferences (user_id, email_enabled, email_opt_out, language, updated_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		ON CONFLICT (user_id) DO UPDATE
+		SET email_enabled = EXCLUDED.email_enabled,
+		    email_opt_out = EXCLUDED.email_opt_out,
+		    language      = EXCLUDED.language,
+		    updated_at    = NOW()
+		RETURNING email_enabled, email_opt_out, language, updated_at
+	`, userID, p.EmailEnabled, pq.Array(p.EmailOptOut), p.Language))
+	return saved, err
+}
+
+func (r *notificationRepository) EmailRecipient(userID string) (model.EmailRecipient, bool, error) {
+	out := model.EmailRecipient{UserID: userID, Preferences: model.DefaultNotificationPreferences()}
+	var (
+		enabled sql.NullBool
+		optOut  pq.StringArray
+		lang    sql.NullString
+	)
+	err := r.db.QueryRow(`
+		SELECT u.email, u.full_name, p.email_enabled, p.email_opt_out, p.language
+		FROM users u
+		LEFT JOIN notification_preferences p ON p.user_id = u.id
+		WHERE u.id = $1
+		  AND u.is_active = TRUE
+		  AND COALESCE(u.email, '') <> ''
+	`, userID).Scan(&out.Email, &out.Name, &enabled, &optOut, &lang)
+	if err == sql.ErrNoRows {
+		return out, false, nil
+	}
+	if err != nil {
+		return out, false, err
+	}
+	if enabled.Valid {
+		out.Preferences.EmailEnabled = enabled.Bool
+		out.Preferences.EmailOptOut = []string(optOut)
+		out.Preferences.Language = lang.String
+	}
+	return out, true, nil
+}
+
+//
+// ===== EVENT NOTIFIKASI =====
+//
+
+// enqueueNotificationEvent dipanggil di transaksi transisi / komentar supaya event ikut commit atau
+// rollback bersama perubahannya. Status kosong = status reference saat ini.
+func enqueueNotificationEvent(tx *sql.Tx, evt model.AchievementEvent) error {
+	_, err := tx.Exec(`
+		INSERT INTO notification_events
+			(event_type, achievement_ref_id, actor_user_id, achievement_status, note, comment_id, created_at, next_attempt_at)
+		VALUES
+			($1, $2, NULLIF($3, '')::uuid,
+			 COALESCE(NULLIF($4, ''), (SELECT status FROM achievement_references WHERE id = $2)),
+			 NULLIF($5, ''), NULLIF($6, '')::uuid, NOW(), NOW())
+	`, evt.Type, evt.AchievementID, evt.ActorUserID, evt.Status, evt.Note, evt.CommentID)
+	return err
+}
+
+func (r *notificationRepository) ClaimEvents(limit int) ([]model.NotificationEvent, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+
+	rows, err := r.db.Query(`
+		UPDATE notification_events e
+		SET locked_until = NOW() + INTERVAL '1 minute',
+		    attempts = e.attempts + 1
+		WHERE e.id IN (
+			SELECT id
+			FROM notification_events
+			WHERE status = 'pending'
+			  AND next_attempt_at <= NOW()
+			  AND (locked_until IS NULL OR locked_until < NOW())
+			ORDER BY created_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING e.id, e.event_type, e.achievement_ref_id, COALESCE(e.actor_user_id::text, ''),
+		          COALESCE(e.achievement_status, ''), COALESCE(e.note, ''), COALESCE(e.comment_id::text, ''),
+		          e.created_at, e.attempts
+	`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []model.NotificationEvent
+	for rows.Next() {
+		var e model.NotificationEvent
+		if err := rows.Scan(&e.ID, &e.Event.Type, &e.Event.AchievementID, &e.Event.ActorUserID,
+			&e.Event.Status, &e.Event.Note, &e.Event.CommentID, &e.Event.At, &e.Attempts); err != nil {
+			return nil, err
+		}
+		out = append(out, e)
+	}
+	return out, rows.Err()
+}
+
+func (r *notificationRepository) MarkEventDone(id string) error {
+	_, err := r.db.Exec(`
+		UPDATE notification_events
+		SET status='done',
+		    processed_at=NOW(),
+		    locked_until=NULL,
+		    last_error=NULL
+		WHERE id=$1
+	`, id)
+	return err
+}
+
+func (r *notificationRepository) MarkEventFailed(id string, cause error, retryAt *time.Time) error {
+	status := "pending"
+	if retryAt == nil {
+		status = "failed"
+	}
+	_, err := r.db.Exec(`
+		UPDATE notification_events
+		SET status=$2,
+		    last_error=$3,
+		    next_attempt_at=COALESCE($4, next_attempt_at),
+		    locked_until=NULL
+		WHERE id=$1
+	`, id, status, cause.Error(), retryAt)
+	return err
+}
+
+func scanPreferences(row rowScanner) (model.NotificationPreferences, bool, error) {
+	var (
+		p         model.NotificationPreferences
+		optOut    pq.StringArray
+		updatedAt time.Time
+	)
+	err := row.Scan(&p.EmailEnabled, &optOut, &p.Language, &updatedAt)
+	if err == sql.ErrNoRows {
+		return p, false, nil
+	}
+	if err != nil {
+		return p, false, err
+	}
+	p.EmailOptOut = append([]string{}, optOut...)
+	p.UpdatedAt = &updatedAt
+	return p, true, nil
+}
+
+func scanNotification(row rowScanner) (*model.Notification, error) {
+	var (
+		n         model.Notification
+		refID     sql.NullString
+		actorID   sql.NullString
+		actorName sql.NullString
+		readAt    sql.NullTime
+	)
+	if err := row.Scan(&n.ID, &n.UserID, &n.Type, &n.Title, &n.Message, &refID,
+		&actorID, &actorName, &readAt, &n.CreatedAt); err != nil {
+		return nil, err
+	}
+	n.AchievementID = nullStringPtr(refID)
+	n.ActorID = nullStringPtr(actorID)
+	n.ActorName = nullStringPtr(actorName)
+	if readAt.Valid {
+		n.Read = true
+		n.ReadAt = &readAt.Time
+	}
+	return &n, nil
+}
+
+func stringValue(p *string) string {
+	if p == nil {
+		return ""
+	}
+	return *p
+}
@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"project_uas/app/model"
+)
+
+type OutboxRepository interface {
+	// ClaimPending mengunci entry pending (refID kosong = semua) supaya tidak diproses replica lain.
+	// Per reference hanya entry terdepan (seq terkecil yang masih pending) yang bisa diklaim, sehingga
+	// entry berikutnya tertahan sampai entry sebelumnya done atau failed.
+	ClaimPending(refID string, limit int) ([]model.OutboxEntry, error)
+	MarkDone(id string) error
+	// MarkFailed retryAt nil = percobaan habis, status failed (dead letter, tidak diklaim lagi)
+	MarkFailed(id string, cause error, retryAt *time.Time) error
+
+	// HasUnapplied true jika reference masih punya entry pending / failed (dokumen Mongo tertinggal).
+	HasUnapplied(refID string) (bool, error)
+
+	// ListApplied entry yang sudah diterapkan (done) untuk reference, urut seq (dipakai reconcile).
+	ListApplied(refID string) ([]model.OutboxEntry, error)
+}
+
+type outboxRepository struct {
+	db *sql.DB
+}
+
+func NewOutboxRepository(db *sql.DB) OutboxRepository {
+	return &outboxRepository{db: db}
+}
+
+func (r *outboxRepository) ClaimPending(refID string, limit int) ([]model.OutboxEntry, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+
+	rows, err := r.db.Query(`
+		UPDATE achievement_outbox o
+		SET locked_until = NOW() + INTERVAL '1 minute',
+		    attempts = o.attempts + 1
+		WHERE o.id IN (
+			SELECT a.id
+			FROM achievement_outbox a
+			WHERE a.status = 'pending'
+			  AND a.next_attempt_at <= NOW()
+			  AND (a.locked_until IS NULL OR a.locked_until < NOW())
+			  AND ($1 = '' OR a.achievement_ref_id::text = $1)
+			  AND NOT EXISTS (
+			      SELECT 1
+			      FROM achievement_outbox b
+			      WHERE b.achievement_ref_id = a.achievement_ref_id
+			        AND b.status = 'pending'
+			        AND b.seq < a.seq
+			  )
+			ORDER BY a.seq
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING o.id, o.achievement_ref_id, o.mongo_achievement_id, o.operation, o.payload, o.attempts
+	`, refID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []model.OutboxEntry
+	for rows.Next() {
+		var e model.OutboxEntry
+		var payload []byte
+		if err := rows.Scan(&e.ID, &e.AchievementRefID, &e.MongoAchievementID, &e.Operation, &payload, &e.Attempts); err != nil {
+			return nil, err
+		}
+		e.Payload = payload
+		out = append(out, e)
+	}
+	return out, rows.Err()
+}
+
+func (r *outboxRepository) MarkDone(id string) error {
+	_, err := r.db.Exec(`
+		UPDATE achievement_outbox
+		SET status='done',
+		    processed_at=NOW(),
+		    locked_until=NULL,
+		    last_error=NULL
+		WHERE id=$1
+	`, id)
+	return err
+}
+
+func (r *outboxRepository) MarkFailed(id string, cause error, retryAt *time.Time) error {
+	status := "pending"
+	if retryAt == nil {
+		status = "failed"
+	}
+	_, err := r.db.Exec(`
+		UPDATE achievement_outbox
+		SET status=$2,
+		    last_error=$3,
+		    next_attempt_at=COALESCE($4, next_attempt_at),
+		    locked_until=NULL
+		WHERE id=$1
+	`, id, status, cause.Error(), retryAt)
+	return err
+}
+
+func (r *outboxRepository) HasUnapplied(refID string) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow(`
+		SELECT EXISTS (
+			SELECT 1
+			FROM achievement_outbox
+			WHERE achievement_ref_id=$1
+			  AND status <> 'done'
+		)
+	`, refID).Scan(&exists)
+	return exists, err
+}
+
+func (r *outboxRepository) ListApplied(refID string) ([]model.OutboxEntry, error) {
+	rows, err := r.db.Query(`
+		SELECT id, achievement_ref_id, mongo_achievement_id, operation, payload, attempts
+		FROM achievement_outbox
+		WHERE achievement_ref_id=$1
+		  AND status='done'
+		ORDER BY seq
+	`, refID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []model.OutboxEntry
+	for rows.Next() {
+		var e model.OutboxEntry
+		var payload []byte
+		if err := rows.Scan(&e.ID, &e.AchievementRefID, &e.MongoAchievementID, &e.Operation, &payload, &e.Attempts); err != nil {
+			return nil, err
+		}
+		e.Payload = payload
+		out = append(out, e)
+	}
+	return out, rows.Err()
+}
+
+// enqueueOutbox dipanggil di dalam transaksi achievement_references supaya perubahan
+// Postgres dan rencana perubahan Mongo commit/rollback bersama.
+func enqueueOutbox(tx *sql.Tx, refID, mongoID, operation string, payload []byte) error {
+	_, err := tx.Exec(`
+		INSERT INTO achievement_outbox
+			(achievement_ref_id, mongo_achievement_id, operation, payload, created_at, next_attempt_at)
+		VALUES
+			($1, $2, $3, $4, NOW(), NOW())
+	`, refID, mongoID, operation, nullableJSON(payload))
+	return err
+}
+
+func nullableJSON(b []byte) any {
+	if len(b) == 0 {
+		return nil
+	}
+	return string(b)
+}
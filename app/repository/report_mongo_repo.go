@@ -58,7 +58,8 @@ func (r *reportMongoRepository) AggregateStatistics(studentIDs []string) (*model
 					{{Key: "$limit", Value: 5}},
 				},
 				"levelDist": mongo.Pipeline{
-					// asumsi tingkat kompetisi disimpan di details.level
+					// details.level divalidasi skema saat create/update (wajib untuk competition)
+					{{Key: "$match", Value: bson.M{"details.level": bson.M{"$nin": bson.A{nil, ""}}}}},
 					{{Key: "$group", Value: bson.M{"_id": "$details.level", "total": bson.M{"$sum": 1}}}},
 					{{Key: "$sort", Value: bson.M{"total": -1}}},
 				},
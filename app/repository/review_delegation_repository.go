@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"project_uas/app/model"
+)
+
+var (
+	ErrLecturerNotFound   = errors.New("lecturer not found")
+	ErrSubstituteNotFound = errors.New("substitute lecturer not found")
+	ErrDelegationOverlap  = errors.New("delegation overlaps an existing active delegation")
+)
+
+type ReviewDelegationRepository interface {
+	// Create menolak periode yang tumpang tindih dengan delegasi aktif lain milik dosen yang sama
+	Create(lecturerID string, req model.ReviewDelegationRequest, createdBy string) (*model.ReviewDelegation, error)
+	// ListByLecturer delegasi dari maupun ke dosen ini, terbaru dulu
+	ListByLecturer(lecturerID string) ([]model.ReviewDelegation, error)
+	Revoke(lecturerID, id string) (bool, error)
+}
+
+type reviewDelegationRepository struct{ db *sql.DB }
+
+func NewReviewDelegationRepository(db *sql.DB) ReviewDelegationRepository {
+	return &reviewDelegationRepository{db: db}
+}
+
+const delegationSelect = `
+	SELECT d.id, d.lecturer_id, lu.full_name, d.substitute_lecturer_id, su.full_name,
+	       d.starts_on, d.ends_on, d.reason, d.created_by, d.created_at, d.revoked_at,
+	       (d.revoked_at IS NULL AND CURRENT_DATE BETWEEN d.starts_on AND d.ends_on)
+	FROM review_delegations d
+	JOIN lecturers l ON l.id = d.lecturer_id
+	JOIN users lu ON lu.id = l.user_id
+	JOIN lecturers sl ON sl.id = d.substitute_lecturer_id
+	JOIN users su ON su.id = sl.user_id
+`
+
+func (r *reviewDelegationRepository) Create(lecturerID string, req model.ReviewDelegationRequest, createdBy string) (*model.ReviewDelegation, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// kunci baris dosen supaya cek tumpang tindih tidak balapan dengan request lain
+	var id string
+	err = tx.QueryRow(`SELECT id FROM lecturers WHERE id::text=$1 FOR UPDATE`, lecturerID).Scan(&id)
+	if err == sql.ErrNoRows {
+		return nil, ErrLecturerNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var exists bool
+	if err := tx.QueryRow(`SELECT EXISTS (SELECT 1 FROM lecturers WHERE id::text=$1)`, req.SubstituteLecturerID).Scan(&exists); err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrSubstituteNotFound
+	}
+
+	var overlap bool
+	err = tx.QueryRow(`
+		SELECT EXISTS (
+			SELECT 1 FROM review_delegations
+			WHERE lecturer_id = $1
+			  AND revoked_at IS NULL
+			  AND starts_on <= $3::date
+			  AND ends_on >= $2::date
+		)
+	`, id, req.StartsOn, req.EndsOn).Scan(&overlap)
+	if err != nil {
+		return nil, err
+	}
+	if overlap {
+		return nil, ErrDelegationOverlap
+	}
+
+	var newID string
+	err = tx.QueryRow(`
+		INSERT INTO review_delegations
+			(lecturer_id, substitute_lecturer_id, starts_on, ends_on, reason, created_by)
+		VALUES ($1, $2::uuid, $3::date, $4::date, NULLIF($5, ''), NULLIF($6, '')::uuid)
+		RETURNING id
+	`, id, req.SubstituteLecturerID, req.StartsOn, req.EndsOn, req.Reason, createdBy).Scan(&newID)
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := scanDelegation(tx.QueryRow(delegationSelect+` WHERE d.id = $1`, newID))
+	if err != nil {
+		return nil, err
+	}
+	return d, tx.Commit()
+}
+
+func (r *reviewDelegationRepository) ListByLecturer(lecturerID string) ([]model.ReviewDelegation, error) {
+	rows, err := r.db.Query(delegationSelect+`
+		WHERE d.lecturer_id::text = $1 OR d.substitute_lecturer_id::text = $1
+		ORDER BY d.starts_on DESC, d.created_at DESC
+	`, lecturerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []model.ReviewDelegation{}
+	for rows.Next() {
+		d, err := scanDelegation(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, *d)
+	}
+	return out, rows.Err()
+}
+
+func (r *reviewDelegationRepository) Revoke(lecturerID, id string) (bool, error) {
+	res, err := r.db.Exec(`
+		UPDATE review_delegations
+		SET revoked_at = NOW()
+		WHERE id::text = $1
+		  AND lecturer_id::text = $2
+		  AND revoked_at IS NULL
+	`, id, lecturerID)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+func scanDelegation(row rowScanner) (*model.ReviewDelegation, error) {
+	var (
+		d         model.ReviewDelegation
+		startsOn  time.Time
+		endsOn    time.Time
+		reason    sql.NullString
+		createdBy sql.NullString
+		revokedAt sql.NullTime
+	)
+	if err := row.Scan(&d.ID, &d.LecturerID, &d.LecturerName, &d.SubstituteLecturerID, &d.SubstituteName,
+		&startsOn, &endsOn, &reason, &createdBy, &d.CreatedAt, &revokedAt, &d.Active); err != nil {
+		return nil, err
+	}
+	d.StartsOn = startsOn.Format("2006-01-02")
+	d.EndsOn = endsOn.Format("2006-01-02")
+	d.Reason = nullStringPtr(reason)
+	d.CreatedBy = nullStringPtr(createdBy)
+	if revokedAt.Valid {
+		d.RevokedAt = &revokedAt.Time
+	}
+	return &d, nil
+}
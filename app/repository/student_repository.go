@@ -110,10 +110,10 @@ func (r *studentRepository) GetAchievements(studentID string, limit, offset int)
 	rows, err := r.db.Query(`
 		SELECT id, student_id, status, created_at
 		FROM achievement_references
-		WHERE student_id=$1 AND status != 'deleted'
+		WHERE student_id=$1 AND status != $4
 		ORDER BY created_at DESC
 		LIMIT $2 OFFSET $3
-	`, studentID, limit, offset)
+	`, studentID, limit, offset, model.StatusDeleted)
 	if err != nil {
 		return nil, err
 	}
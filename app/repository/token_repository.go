@@ -0,0 +1,279 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"project_uas/app/model"
+)
+
+// TokenRevocationChannel channel LISTEN/NOTIFY pencabutan token (payload = model.TokenRevocation JSON)
+const TokenRevocationChannel = "token_revocations"
+
+var (
+	ErrRefreshTokenInvalid = errors.New("refresh token is invalid or revoked")
+	ErrRefreshTokenReused  = errors.New("refresh token reuse detected")
+)
+
+// TokenRepository token store JWT: family refresh token, denylist access token, batas per user.
+// Setiap pencabutan di-NOTIFY di transaksinya supaya cache replika lain ikut diperbarui.
+type TokenRepository interface {
+	// StartFamily family (sesi login) baru beserta refresh token pertamanya
+	StartFamily(t model.RefreshTokenRecord) error
+	// Rotate tandai oldJTI terpakai dan simpan penggantinya. oldJTI yang sudah pernah dirotasi
+	// -> seluruh family dicabut dan ErrRefreshTokenReused.
+	Rotate(oldJTI string, next model.RefreshTokenRecord) error
+	// RevokeFamily false = family tidak ada / sudah dicabut
+	RevokeFamily(familyID, userID, reason string, at time.Time) (bool, error)
+	RevokeAccessToken(jti, userID string, expiresAt time.Time) error
+	// RevokeUser cabut semua family user dan tolak access token dengan iat < at (at tidak dibulatkan ke detik)
+	RevokeUser(userID, reason string, at time.Time) (bool, error)
+	// ListRevocations pencabutan yang masih memengaruhi access token: denylist yang belum
+	// kedaluwarsa, family dan batas user sejak since
+	ListRevocations(since time.Time) ([]model.TokenRevocation, error)
+	// Prune hapus token yang sudah kedaluwarsa
+	Prune() (int64, error)
+}
+
+type tokenRepository struct {
+	db *sql.DB
+}
+
+func NewTokenRepository(db *sql.DB) TokenRepository {
+	return &tokenRepository{db: db}
+}
+
+//
+// ===== REFRESH TOKEN =====
+//
+
+func (r *tokenRepository) StartFamily(t model.RefreshTokenRecord) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`
+		INSERT INTO refresh_token_families (id, user_id, created_at)
+		VALUES ($1, $2, $3)
+	`, t.FamilyID, t.UserID, t.IssuedAt); err != nil {
+		return err
+	}
+	if err := insertRefreshToken(tx, t); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *tokenRepository) Rotate(oldJTI string, next model.RefreshTokenRecord) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// kunci family: rotasi paralel token yang sama berurutan, yang kedua terdeteksi reuse
+	var (
+		familyID, userID string
+		usedAt, revoked  sql.NullTime
+	)
+	err = tx.QueryRow(`
+		SELECT t.family_id, f.user_id, t.used_at, f.revoked_at
+		FROM refresh_tokens t
+		JOIN refresh_token_families f ON f.id = t.family_id
+		WHERE t.jti = $1
+		  AND t.expires_at > NOW()
+		FOR UPDATE OF t, f
+	`, oldJTI).Scan(&familyID, &userID, &usedAt, &revoked)
+	if err == sql.ErrNoRows {
+		return ErrRefreshTokenInvalid
+	}
+	if err != nil {
+		return err
+	}
+	if revoked.Valid || familyID != next.FamilyID || userID != next.UserID {
+		return ErrRefreshTokenInvalid
+	}
+
+	if usedAt.Valid {
+		if _, err := revokeFamily(tx, familyID, userID, model.TokenRevokeReuse, next.IssuedAt); err != nil {
+			return err
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+		return ErrRefreshTokenReused
+	}
+
+	if _, err := tx.Exec(`UPDATE refresh_tokens SET used_at = $2 WHERE jti = $1`, oldJTI, next.IssuedAt); err != nil {
+		return err
+	}
+	next.ParentJTI = oldJTI
+	if err := insertRefreshToken(tx, next); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func insertRefreshToken(tx *sql.Tx, t model.RefreshTokenRecord) error {
+	_, err := tx.Exec(`
+		INSERT INTO refresh_tokens (jti, family_id, parent_jti, issued_at, expires_at)
+		VALUES ($1, $2, NULLIF($3, '')::uuid, $4, $5)
+	`, t.JTI, t.FamilyID, t.ParentJTI, t.IssuedAt, t.ExpiresAt)
+	return err
+}
+
+//
+// ===== REVOCATION =====
+//
+
+func (r *tokenRepository) RevokeFamily(familyID, userID, reason string, at time.Time) (bool, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	ok, err := revokeFamily(tx, familyID, userID, reason, at)
+	if err != nil {
+		return false, err
+	}
+	return ok, tx.Commit()
+}
+
+func revokeFamily(tx *sql.Tx, familyID, userID, reason string, at time.Time) (bool, error) {
+	res, err := tx.Exec(`
+		UPDATE refresh_token_families
+		SET revoked_at = $3, revoke_reason = $4
+		WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL
+	`, familyID, userID, at, reason)
+	if err != nil {
+		return false, err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return false, nil
+	}
+	return true, notifyTokenRevocation(tx, model.TokenRevocation{Kind: model.TokenRevocationFamily, ID: familyID, At: at})
+}
+
+func (r *tokenRepository) RevokeAccessToken(jti, userID string, expiresAt time.Time) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`
+		INSERT INTO revoked_access_tokens (jti, user_id, expires_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (jti) DO NOTHING
+	`, jti, userID, expiresAt); err != nil {
+		return err
+	}
+	if err := notifyTokenRevocation(tx, model.TokenRevocation{Kind: model.TokenRevocationAccess, ID: jti, At: expiresAt}); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *tokenRepository) RevokeUser(userID, reason string, at time.Time) (bool, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec(`
+		UPDATE users
+		SET tokens_revoked_at = GREATEST(COALESCE(tokens_revoked_at, $2), $2)
+		WHERE id = $1
+	`, userID, at)
+	if err != nil {
+		return false, err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return false, nil
+	}
+
+	if _, err := tx.Exec(`
+		UPDATE refresh_token_families
+		SET revoked_at = $2, revoke_reason = $3
+		WHERE user_id = $1 AND revoked_at IS NULL
+	`, userID, at, reason); err != nil {
+		return false, err
+	}
+	// access token family tersebut sudah tertutup batas iat user, cukup satu notifikasi
+	if err := notifyTokenRevocation(tx, model.TokenRevocation{Kind: model.TokenRevocationUser, ID: userID, At: at}); err != nil {
+		return false, err
+	}
+	return true, tx.Commit()
+}
+
+// notifyTokenRevocation NOTIFY baru terkirim saat commit
+func notifyTokenRevocation(tx *sql.Tx, rev model.TokenRevocation) error {
+	payload, err := json.Marshal(rev)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(`SELECT pg_notify('`+TokenRevocationChannel+`', $1)`, string(payload))
+	return err
+}
+
+func (r *tokenRepository) ListRevocations(since time.Time) ([]model.TokenRevocation, error) {
+	rows, err := r.db.Query(`
+		SELECT $2::text, jti::text, expires_at
+		FROM revoked_access_tokens
+		WHERE expires_at > NOW()
+		UNION ALL
+		SELECT $3::text, id::text, revoked_at
+		FROM refresh_token_families
+		WHERE revoked_at > $1
+		UNION ALL
+		SELECT $4::text, id::text, tokens_revoked_at
+		FROM users
+		WHERE tokens_revoked_at > $1
+	`, since, model.TokenRevocationAccess, model.TokenRevocationFamily, model.TokenRevocationUser)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []model.TokenRevocation{}
+	for rows.Next() {
+		var rev model.TokenRevocation
+		if err := rows.Scan(&rev.Kind, &rev.ID, &rev.At); err != nil {
+			return nil, err
+		}
+		out = append(out, rev)
+	}
+	return out, rows.Err()
+}
+
+func (r *tokenRepository) Prune() (int64, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var total int64
+	for _, q := range []string{
+		`DELETE FROM revoked_access_tokens WHERE expires_at < NOW()`,
+		`DELETE FROM refresh_tokens WHERE expires_at < NOW()`,
+		// family tanpa token tersisa tidak bisa dipakai lagi
+		`DELETE FROM refresh_token_families f
+		 WHERE NOT EXISTS (SELECT 1 FROM refresh_tokens t WHERE t.family_id = f.id)
+		   AND f.created_at < NOW() - INTERVAL '1 day'`,
+	} {
+		res, err := tx.Exec(q)
+		if err != nil {
+			return 0, err
+		}
+		n, _ := res.RowsAffected()
+		total += n
+	}
+	return total, tx.Commit()
+}
@@ -0,0 +1,454 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"project_uas/app/model"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// WebhookRepository langganan webhook + antrian pengiriman + log percobaan.
+// Delivery dibuat enqueueWebhookEvent di transaksi transisi prestasi (insertHistory).
+type WebhookRepository interface {
+	List() ([]model.WebhookSubscription, error)
+	GetByID(id string) (*model.WebhookSubscription, bool, error)
+	Create(s *model.WebhookSubscription) error
+	// Update secret kosong = secret lama tetap
+	Update(s *model.WebhookSubscription) (bool, error)
+	Deactivate(id string) (bool, error)
+
+	// ClaimPending mengunci delivery pending (id kosong = semua) milik langganan aktif, attempts naik.
+	// Delivery yang detailnya belum ditempel menunggu entry outbox event tersebut diterapkan ke Mongo.
+	ClaimPending(id string, limit int) ([]model.WebhookDispatch, error)
+	// AttachDetail tempel detail Mongo (JSON, boleh null) ke payload semua delivery event tersebut
+	// yang belum punya detail; mengembalikan payload delivery id (penempel pertama yang menang).
+	AttachDetail(eventID, id string, detail []byte) ([]byte, error)
+	MarkDelivered(id string, a model.WebhookDeliveryAttempt) error
+	// MarkFailed retryAt nil = percobaan habis, status failed
+	MarkFailed(id string, a model.WebhookDeliveryAttempt, retryAt *time.Time) error
+
+	ListDeliveries(subscriptionID, status string, limit, offset int) ([]model.WebhookDelivery, int, error)
+	// GetDelivery termasuk payload dan log percobaan
+	GetDelivery(subscriptionID, id string) (*model.WebhookDelivery, bool, error)
+	// Redeliver delivery baru dengan payload (dan event id) yang sama
+	Redeliver(subscriptionID, id string) (string, bool, error)
+}
+
+type webhookRepository struct {
+	db *sql.DB
+}
+
+func NewWebhookRepository(db *sql.DB) WebhookRepository {
+	return &webhookRepository{db: db}
+}
+
+//
+// ===== SUBSCRIPTIONS =====
+//
+
+const webhookSelect = `
+	SELECT id, name, url, events, is_active, created_by, created_at, updated_at
+	FROM webhook_subscriptions
+`
+
+func (r *webhookRepository) List() ([]model.WebhookSubscription, error) {
+	return r.querySubscriptions(webhookSelect + `ORDER BY created_at`)
+}
+
+func (r *webhookRepository) querySubscriptions(query string, args ...any) ([]model.WebhookSubscription, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []model.WebhookSubscription{}
+	for rows.Next() {
+		s, err := scanWebhook(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, *s)
+	}
+	return out, rows.Err()
+}
+
+func (r *webhookRepository) GetByID(id string) (*model.WebhookSubscription, bool, error) {
+	s, err := scanWebhook(r.db.QueryRow(webhookSelect+`WHERE id::text = $1`, id))
+	if err == sql.ErrNoRows {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return s, true, nil
+}
+
+func (r *webhookRepository) Create(s *model.WebhookSubscription) error {
+	return r.db.QueryRow(`
+		INSERT INTO webhook_subscriptions (name, url, secret, events, is_active, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, '')::uuid, NOW(), NOW())
+		RETURNING id, created_at, updated_at
+	`, s.Name, s.URL, s.Secret, pq.Array(s.Events), s.IsActive, stringValue(s.CreatedBy)).
+		Scan(&s.ID, &s.CreatedAt, &s.UpdatedAt)
+}
+
+func (r *webhookRepository) Update(s *model.WebhookSubscription) (bool, error) {
+	var createdBy sql.NullString
+	err := r.db.QueryRow(`
+		UPDATE webhook_subscriptions
+		SET name=$2,
+		    url=$3,
+		    secret=COALESCE(NULLIF($4, ''), secret),
+		    events=$5,
+		    is_active=$6,
+		    updated_at=NOW()
+		WHERE id::text = $1
+		RETURNING created_by, created_at, updated_at
+	`, s.ID, s.Name, s.URL, s.Secret, pq.Array(s.Events), s.IsActive).Scan(&createdBy, &s.CreatedAt, &s.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	s.CreatedBy = nullStringPtr(createdBy)
+	return true, nil
+}
+
+func (r *webhookRepository) Deactivate(id string) (bool, error) {
+	res, err := r.db.Exec(`
+		UPDATE webhook_subscriptions
+		SET is_active=FALSE, updated_at=NOW()
+		WHERE id::text = $1
+	`, id)
+	if err != nil {
+		return false, err
+	}
+	n, _ := res.RowsAffected()
+	return n > 0, nil
+}
+
+//
+// ===== DELIVERIES =====
+//
+
+// webhookEventFilter langganan aktif yang filternya cocok dengan event $1 (events kosong = semua)
+const webhookEventFilter = `is_active AND (cardinality(events) = 0 OR $1 = ANY(events))`
+
+// enqueueWebhookEvent satu delivery per langganan yang cocok, di transaksi transisi: event tidak
+// hilang walau proses mati sesudah commit, dan reference dibekukan dari baris yang baru di-update.
+// Semua langganan mendapat payload dan event id yang sama.
+func enqueueWebhookEvent(tx *sql.Tx, eventType, refID, actorUserID string) error {
+	var subscribed bool
+	if err := tx.QueryRow(`
+		SELECT EXISTS (SELECT 1 FROM webhook_subscriptions WHERE `+webhookEventFilter+`)
+	`, eventType).Scan(&subscribed); err != nil {
+		return err
+	}
+	if !subscribed {
+		return nil
+	}
+
+	ref, err := selectReference(tx, refID)
+	if err != nil {
+		return err
+	}
+	eventID := uuid.NewString()
+	payload, err := json.Marshal(model.WebhookPayload{
+		ID:         eventID,
+		Event:      eventType,
+		OccurredAt: time.Now(),
+		ActorID:    actorUserID,
+		Data:       model.WebhookPayloadData{Reference: ref},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO webhook_deliveries
+			(subscription_id, event_id, event_type, achievement_ref_id, payload, detail_attached, created_at, next_attempt_at)
+		SELECT id, $2, $1, $3, $4, FALSE, NOW(), NOW()
+		FROM webhook_subscriptions
+		WHERE `+webhookEventFilter+`
+	`, eventType, eventID, refID, string(payload))
+	return err
+}
+
+func (r *webhookRepository) ClaimPending(id string, limit int) ([]model.WebhookDispatch, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+
+	rows, err := r.db.Query(`
+		UPDATE webhook_deliveries d
+		SET locked_until = NOW() + INTERVAL '2 minutes',
+		    attempts = d.attempts + 1
+		FROM webhook_subscriptions s
+		WHERE s.id = d.subscription_id
+		  AND d.id IN (
+			SELECT wd.id
+			FROM webhook_deliveries wd
+			JOIN webhook_subscriptions ws ON ws.id = wd.subscription_id
+			WHERE wd.status = 'pending'
+			  AND ws.is_active
+			  AND wd.next_attempt_at <= NOW()
+			  AND (wd.locked_until IS NULL OR wd.locked_until < NOW())
+			  AND ($1 = '' OR wd.id::text = $1)
+			  -- created_at = NOW() transaksi transisi: termasuk entry outbox di transaksi yang sama.
+			  -- Entry failed (percobaan habis) tidak lagi menahan: detail dikirim apa adanya dari Mongo.
+			  AND (wd.detail_attached OR NOT EXISTS (
+				SELECT 1
+				FROM achievement_outbox o
+				WHERE o.achievement_ref_id = wd.achievement_ref_id
+				  AND o.status = 'pending'
+				  AND o.created_at <= wd.created_at
+			  ))
+			ORDER BY wd.created_at
+			LIMIT $2
+			FOR UPDATE OF wd SKIP LOCKED
+		)
+		RETURNING d.id, d.event_id, d.event_type, s.url, s.secret, d.payload, d.attempts, d.detail_attached
+	`, id, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []model.WebhookDispatch
+	for rows.Next() {
+		var d model.WebhookDispatch
+		if err := rows.Scan(&d.ID, &d.EventID, &d.EventType, &d.URL, &d.Secret, &d.Payload, &d.Attempts, &d.DetailAttached); err != nil {
+			return nil, err
+		}
+		out = append(out, d)
+	}
+	return out, rows.Err()
+}
+
+func (r *webhookRepository) AttachDetail(eventID, id string, detail []byte) ([]byte, error) {
+	if _, err := r.db.Exec(`
+		UPDATE webhook_deliveries
+		SET payload = jsonb_set(payload, '{data,detail}', $2::jsonb),
+		    detail_attached = TRUE
+		WHERE event_id::text = $1
+		  AND NOT detail_attached
+	`, eventID, string(detail)); err != nil {
+		return nil, err
+	}
+
+	var payload []byte
+	err := r.db.QueryRow(`SELECT payload FROM webhook_deliveries WHERE id::text = $1`, id).Scan(&payload)
+	return payload, err
+}
+
+func (r *webhookRepository) MarkDelivered(id string, a model.WebhookDeliveryAttempt) error {
+	return r.recordAttempt(id, a, `
+		UPDATE webhook_deliveries
+		SET status='delivered',
+		    last_status_code=$2,
+		    last_error=NULL,
+		    locked_until=NULL,
+		    delivered_at=NOW()
+		WHERE id=$1
+	`, id, a.StatusCode)
+}
+
+func (r *webhookRepository) MarkFailed(id string, a model.WebhookDeliveryAttempt, retryAt *time.Time) error {
+	status := model.WebhookDeliveryPending
+	if retryAt == nil {
+		status = model.WebhookDeliveryFailed
+	}
+	return r.recordAttempt(id, a, `
+		UPDATE webhook_deliveries
+		SET status=$2,
+		    last_status_code=$3,
+		    last_error=$4,
+		    next_attempt_at=COALESCE($5, next_attempt_at),
+		    locked_until=NULL
+		WHERE id=$1
+	`, id, status, a.StatusCode, a.Error, retryAt)
+}
+
+// recordAttempt log percobaan + update status delivery dalam satu transaksi
+func (r *webhookRepository) recordAttempt(id string, a model.WebhookDeliveryAttempt, update string, args ...any) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`
+		INSERT INTO webhook_delivery_attempts
+			(delivery_id, attempt, status_code, error, response_body, duration_ms, attempted_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`, id, a.Attempt, a.StatusCode, a.Error, a.ResponseBody, a.DurationMs, a.AttemptedAt); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(update, args...); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+const webhookDeliverySelect = `
+	SELECT id, subscription_id, event_id, event_type, achievement_ref_id, status, attempts,
+	       last_status_code, last_error, next_attempt_at, redelivery_of, created_at, delivered_at
+	FROM webhook_deliveries
+`
+
+func (r *webhookRepository) ListDeliveries(subscriptionID, status string, limit, offset int) ([]model.WebhookDelivery, int, error) {
+	var total int
+	if err := r.db.QueryRow(`
+		SELECT COUNT(*)
+		FROM webhook_deliveries
+		WHERE subscription_id::text = $1
+		  AND ($2 = '' OR status = $2)
+	`, subscriptionID, status).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := r.db.Query(webhookDeliverySelect+`
+		WHERE subscription_id::text = $1
+		  AND ($2 = '' OR status = $2)
+		ORDER BY created_at DESC, id DESC
+		LIMIT $3 OFFSET $4
+	`, subscriptionID, status, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	out := []model.WebhookDelivery{}
+	for rows.Next() {
+		d, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		out = append(out, *d)
+	}
+	return out, total, rows.Err()
+}
+
+func (r *webhookRepository) GetDelivery(subscriptionID, id string) (*model.WebhookDelivery, bool, error) {
+	d, err := scanWebhookDelivery(r.db.QueryRow(webhookDeliverySelect+`
+		WHERE id::text = $1
+		  AND subscription_id::text = $2
+	`, id, subscriptionID))
+	if err == sql.ErrNoRows {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	var payload []byte
+	if err := r.db.QueryRow(`SELECT payload FROM webhook_deliveries WHERE id=$1`, d.ID).Scan(&payload); err != nil {
+		return nil, false, err
+	}
+	d.Payload = payload
+
+	rows, err := r.db.Query(`
+		SELECT attempt, status_code, error, response_body, duration_ms, attempted_at
+		FROM webhook_delivery_attempts
+		WHERE delivery_id = $1
+		ORDER BY attempt, attempted_at
+	`, d.ID)
+	if err != nil {
+		return nil, false, err
+	}
+	defer rows.Close()
+
+	d.Log = []model.WebhookDeliveryAttempt{}
+	for rows.Next() {
+		var (
+			a    model.WebhookDeliveryAttempt
+			code sql.NullInt64
+			msg  sql.NullString
+			body sql.NullString
+		)
+		if err := rows.Scan(&a.Attempt, &code, &msg, &body, &a.DurationMs, &a.AttemptedAt); err != nil {
+			return nil, false, err
+		}
+		a.StatusCode = nullIntPtr(code)
+		a.Error = nullStringPtr(msg)
+		a.ResponseBody = nullStringPtr(body)
+		d.Log = append(d.Log, a)
+	}
+	return d, true, rows.Err()
+}
+
+func (r *webhookRepository) Redeliver(subscriptionID, id string) (string, bool, error) {
+	var newID string
+	err := r.db.QueryRow(`
+		INSERT INTO webhook_deliveries
+			(subscription_id, event_id, event_type, achievement_ref_id, payload, detail_attached, redelivery_of, created_at, next_attempt_at)
+		SELECT subscription_id, event_id, event_type, achievement_ref_id, payload, detail_attached, id, NOW(), NOW()
+		FROM webhook_deliveries
+		WHERE id::text = $1
+		  AND subscription_id::text = $2
+		RETURNING id
+	`, id, subscriptionID).Scan(&newID)
+	if err == sql.ErrNoRows {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return newID, true, nil
+}
+
+func scanWebhook(row rowScanner) (*model.WebhookSubscription, error) {
+	var (
+		s         model.WebhookSubscription
+		events    pq.StringArray
+		createdBy sql.NullString
+	)
+	if err := row.Scan(&s.ID, &s.Name, &s.URL, &events, &s.IsActive, &createdBy, &s.CreatedAt, &s.UpdatedAt); err != nil {
+		return nil, err
+	}
+	s.Events = append([]string{}, events...)
+	s.CreatedBy = nullStringPtr(createdBy)
+	return &s, nil
+}
+
+func scanWebhookDelivery(row rowScanner) (*model.WebhookDelivery, error) {
+	var (
+		d           model.WebhookDelivery
+		refID       sql.NullString
+		code        sql.NullInt64
+		lastError   sql.NullString
+		nextAttempt sql.NullTime
+		redelivery  sql.NullString
+		deliveredAt sql.NullTime
+	)
+	if err := row.Scan(&d.ID, &d.SubscriptionID, &d.EventID, &d.EventType, &refID, &d.Status, &d.Attempts,
+		&code, &lastError, &nextAttempt, &redelivery, &d.CreatedAt, &deliveredAt); err != nil {
+		return nil, err
+	}
+	d.AchievementID = nullStringPtr(refID)
+	d.LastStatusCode = nullIntPtr(code)
+	d.LastError = nullStringPtr(lastError)
+	d.RedeliveryOf = nullStringPtr(redelivery)
+	// next_attempt_at hanya bermakna selama masih pending
+	if nextAttempt.Valid && d.Status == model.WebhookDeliveryPending {
+		d.NextAttemptAt = &nextAttempt.Time
+	}
+	if deliveredAt.Valid {
+		d.DeliveredAt = &deliveredAt.Time
+	}
+	return &d, nil
+}
+
+func nullIntPtr(v sql.NullInt64) *int {
+	if !v.Valid {
+		return nil
+	}
+	n := int(v.Int64)
+	return &n
+}
@@ -0,0 +1,201 @@
+package service
+
+import (
+	"errors"
+	"strings"
+
+	"project_uas/app/model"
+	"project_uas/app/repository"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+//
+// ===== APPROVAL CHAIN =====
+// Setelah dosen wali verify, prestasi yang cocok dengan aturan approval-stages menunggu di
+// awaiting_approval. Tiap tahap diputuskan berurutan oleh pemegang permission tahap tersebut.
+//
+
+// getPermissions daftar permission dari JWT (sama dengan yang dicek RequirePermission)
+func getPermissions(c *fiber.Ctx) []string {
+	token, ok := c.Locals("user").(*jwt.Token)
+	if !ok {
+		return nil
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil
+	}
+	raw, _ := claims["permissions"].([]interface{})
+	perms := make([]string, 0, len(raw))
+	for _, p := range raw {
+		if s, ok := p.(string); ok {
+			perms = append(perms, s)
+		}
+	}
+	return perms
+}
+
+// ApproveAchievementStage godoc
+// @Summary Approve current approval stage (awaiting_approval -> awaiting_approval / verified)
+// @Description Memutuskan tahap pending paling awal; user (role apa pun) harus punya permission tahap tersebut.
+// @Description Tahap terakhir yang disetujui membuat status verified dan poin dikirim ke detail prestasi.
+// @Tags Achievements
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Achievement ID"
+// @Param body body model.ApprovalDecisionRequest false "Catatan (opsional)"
+// @Success 200 {object} model.MessageResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 409 {object} model.TransitionErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /achievements/{id}/approval/approve [post]
+func (s *AchievementService) ApproveAchievementStage(c *fiber.Ctx) error {
+	role, userID, ok := getRoleAndUserID(c)
+	if !ok {
+		return c.SendStatus(fiber.StatusUnauthorized)
+	}
+	refID := c.Params("id")
+
+	var body model.ApprovalDecisionRequest
+	_ = c.BodyParser(&body)
+	body.Note = strings.TrimSpace(body.Note)
+
+	perms := getPermissions(c)
+	ref, err := s.resolveStageRef(refID, perms)
+	if err != nil {
+		return respondRefError(c, err)
+	}
+	if _, err := model.AchievementWorkflow.Fire(model.ActionApproveStage, ref.Status, role, model.TransitionContext{Note: body.Note}); err != nil {
+		return respondTransitionError(c, err)
+	}
+
+	verified, err := s.Repo.ApproveStage(refID, userID, body.Note, perms)
+	if err != nil {
+		return respondStageError(c, err, "cannot approve")
+	}
+	if !verified {
+		s.publish(model.EventAchievementAwaitingApproval, refID, userID, model.StatusAwaitingApproval, "")
+		return c.JSON(fiber.Map{"message": "approval stage approved, awaiting next stage"})
+	}
+	s.Outbox.Dispatch(refID)
+	s.publish(model.EventAchievementVerified, refID, userID, model.StatusVerified, "")
+
+	return c.JSON(fiber.Map{"message": "achievement verified"})
+}
+
+// RejectAchievementStage godoc
+// @Summary Reject at current approval stage (awaiting_approval -> rejected)
+// @Description Wajib note. Tahap berikutnya dibatalkan; mahasiswa bisa revisi lalu submit ulang (rantai dimulai lagi dari dosen wali).
+// @Tags Achievements
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Achievement ID"
+// @Param body body model.ApprovalDecisionRequest true "Alasan penolakan"
+// @Success 200 {object} model.MessageResponse
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 409 {object} model.TransitionErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /achievements/{id}/approval/reject [post]
+func (s *AchievementService) RejectAchievementStage(c *fiber.Ctx) error {
+	role, userID, ok := getRoleAndUserID(c)
+	if !ok {
+		return c.SendStatus(fiber.StatusUnauthorized)
+	}
+	refID := c.Params("id")
+
+	var body model.ApprovalDecisionRequest
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(400).JSON(fiber.Map{"message": "note is required"})
+	}
+	body.Note = strings.TrimSpace(body.Note)
+
+	perms := getPermissions(c)
+	ref, err := s.resolveStageRef(refID, perms)
+	if err != nil {
+		return respondRefError(c, err)
+	}
+	if _, err := model.AchievementWorkflow.Fire(model.ActionRejectStage, ref.Status, role, model.TransitionContext{Note: body.Note}); err != nil {
+		return respondTransitionError(c, err)
+	}
+
+	if err := s.Repo.RejectStage(refID, userID, body.Note, perms); err != nil {
+		return respondStageError(c, err, "cannot reject")
+	}
+	s.publish(model.EventAchievementRejected, refID, userID, model.StatusRejected, body.Note)
+	return c.JSON(fiber.Map{"message": "achievement rejected"})
+}
+
+func respondStageError(c *fiber.Ctx, err error, message string) error {
+	if errors.Is(err, repository.ErrApprovalStageForbidden) {
+		return c.Status(403).JSON(fiber.Map{"message": err.Error()})
+	}
+	return respondActionError(c, err, message)
+}
+
+// GetApprovalQueue godoc
+// @Summary Approval queue (reviewer tahap persetujuan)
+// @Description Prestasi awaiting_approval yang tahap berjalannya butuh permission milik user, paling lama menunggu dulu.
+// @Tags Achievements
+// @Security BearerAuth
+// @Produce json
+// @Param limit query int false "Limit (maks 100)" default(50)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} model.ApprovalQueueResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /achievements/approvals [get]
+func (s *AchievementService) GetApprovalQueue(c *fiber.Ctx) error {
+	if _, _, ok := getRoleAndUserID(c); !ok {
+		return c.SendStatus(fiber.StatusUnauthorized)
+	}
+
+	limit := c.QueryInt("limit", 50)
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+	offset := c.QueryInt("offset", 0)
+	if offset < 0 {
+		offset = 0
+	}
+
+	items, total, err := s.Repo.ListApprovalQueue(getPermissions(c), limit, offset)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"message": "failed to fetch approval queue"})
+	}
+
+	ids := make([]primitive.ObjectID, 0, len(items))
+	for _, it := range items {
+		if oid, err := primitive.ObjectIDFromHex(it.MongoAchievementID); err == nil {
+			ids = append(ids, oid)
+		}
+	}
+	docs, err := s.MongoRepo.FindSummaries(ids)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"message": "failed to fetch achievement details"})
+	}
+	for i := range items {
+		if oid, err := primitive.ObjectIDFromHex(items[i].MongoAchievementID); err == nil {
+			if doc, ok := docs[oid]; ok {
+				items[i].Title = doc.Title
+				items[i].AchievementType = doc.AchievementType
+				items[i].Points = doc.Points
+				items[i].Tags = doc.Tags
+			}
+		}
+	}
+
+	return c.JSON(model.ApprovalQueueResponse{
+		Data: items,
+		Meta: model.ApprovalQueueMeta{Total: total, Limit: limit, Offset: offset},
+	})
+}
@@ -0,0 +1,588 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"mime/multipart"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"project_uas/app/model"
+	"project_uas/storage"
+	"project_uas/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	signedURLDefaultTTL = 5 * time.Minute
+	signedURLMaxTTL     = time.Hour
+)
+
+//
+// ===== UPLOAD ATTACHMENT (DRAFT / REVISION) =====
+//
+
+// UploadAchievementAttachment godoc
+// @Summary Upload attachment (draft / revision only)
+// @Description Hanya PDF, PNG, JPEG, WebP (dicek dari isi file). Batas ukuran per tipe dan jumlah lampiran per prestasi dikonfigurasi lewat env. File discan virus sebelum disimpan; file terinfeksi dikarantina dan ditolak (422).
+// @Tags Achievements
+// @Security BearerAuth
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "Achievement ID"
+// @Param file formData file true "Attachment file"
+// @Success 201 {object} model.AttachmentResponse
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 409 {object} model.TransitionErrorResponse
+// @Failure 413 {object} model.ErrorResponse
+// @Failure 415 {object} model.UnsupportedMediaTypeResponse
+// @Failure 422 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Failure 503 {object} model.ErrorResponse
+// @Router /achievements/{id}/attachments [post]
+func (s *AchievementService) UploadAchievementAttachment(c *fiber.Ctx) error {
+	refID := c.Params("id")
+
+	role, userID, ok := getRoleAndUserID(c)
+	if !ok {
+		return c.SendStatus(fiber.StatusUnauthorized)
+	}
+
+	ref, err := s.resolveRef(role, userID, refID, getPermissions(c))
+	if err != nil {
+		return respondRefError(c, err)
+	}
+	if _, err := model.AchievementWorkflow.Fire(model.ActionEdit, ref.Status, role, model.TransitionContext{}); err != nil {
+		return respondTransitionError(c, err)
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"message": "file is required"})
+	}
+
+	oid, err := primitive.ObjectIDFromHex(*ref.MongoID)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"message": "invalid mongo id"})
+	}
+
+	// cek awal supaya file tidak di-upload percuma; batas tetap ditegakkan atomic di AddAttachment
+	doc, err := s.MongoRepo.FindByID(oid)
+	if err != nil {
+		return c.Status(404).JSON(fiber.Map{"message": "achievement detail not found"})
+	}
+	if len(doc.Attachments) >= s.Policy.MaxCount {
+		return respondAttachmentLimit(c, s.Policy.MaxCount)
+	}
+
+	attachment, err := s.saveUpload(c.UserContext(), refID, file)
+	if err != nil {
+		return s.respondUploadError(c, err)
+	}
+	attachment.ID = uuid.New().String()
+
+	added, err := s.MongoRepo.AddAttachment(oid, *attachment, s.Policy.MaxCount)
+	if err != nil || !added {
+		s.deleteFiles(c.UserContext(), *attachment)
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{"message": "failed to attach file"})
+		}
+		return respondAttachmentLimit(c, s.Policy.MaxCount)
+	}
+
+	resolveAttachmentURL(refID, attachment)
+	return c.Status(201).JSON(fiber.Map{"data": attachment})
+}
+
+//
+// ===== REPLACE / DELETE ATTACHMENT (DRAFT / REVISION) =====
+//
+
+// ReplaceAchievementAttachment godoc
+// @Summary Replace attachment (draft / revision only)
+// @Description File lama dihapus dari storage; id lampiran tetap sama. Validasi sama dengan upload.
+// @Tags Achievements
+// @Security BearerAuth
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "Achievement ID"
+// @Param attachmentId path string true "Attachment ID"
+// @Param file formData file true "Attachment file"
+// @Success 200 {object} model.AttachmentResponse
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 409 {object} model.TransitionErrorResponse
+// @Failure 413 {object} model.ErrorResponse
+// @Failure 415 {object} model.UnsupportedMediaTypeResponse
+// @Failure 422 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Failure 503 {object} model.ErrorResponse
+// @Router /achievements/{id}/attachments/{attachmentId} [put]
+func (s *AchievementService) ReplaceAchievementAttachment(c *fiber.Ctx) error {
+	refID := c.Params("id")
+	attachmentID := attachmentIDParam(c)
+
+	role, userID, ok := getRoleAndUserID(c)
+	if !ok {
+		return c.SendStatus(fiber.StatusUnauthorized)
+	}
+
+	ref, err := s.resolveRef(role, userID, refID, getPermissions(c))
+	if err != nil {
+		return respondRefError(c, err)
+	}
+	if _, err := model.AchievementWorkflow.Fire(model.ActionEdit, ref.Status, role, model.TransitionContext{}); err != nil {
+		return respondTransitionError(c, err)
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"message": "file is required"})
+	}
+
+	oid, err := primitive.ObjectIDFromHex(*ref.MongoID)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"message": "invalid mongo id"})
+	}
+
+	attachment, err := s.saveUpload(c.UserContext(), refID, file)
+	if err != nil {
+		return s.respondUploadError(c, err)
+	}
+
+	old, found, err := s.MongoRepo.ReplaceAttachment(oid, attachmentID, *attachment)
+	if err != nil || !found {
+		s.deleteFiles(c.UserContext(), *attachment)
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{"message": "failed to replace attachment"})
+		}
+		return c.Status(404).JSON(fiber.Map{"message": "attachment not found"})
+	}
+	s.deleteFiles(c.UserContext(), *old)
+
+	attachment.ID = attachmentID
+	resolveAttachmentURL(refID, attachment)
+	return c.JSON(fiber.Map{"data": attachment})
+}
+
+// DeleteAchievementAttachment godoc
+// @Summary Delete attachment (draft / revision only)
+// @Description Lampiran dilepas dari prestasi dan file-nya dihapus dari storage.
+// @Tags Achievements
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Achievement ID"
+// @Param attachmentId path string true "Attachment ID"
+// @Success 200 {object} model.MessageResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 409 {object} model.TransitionErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /achievements/{id}/attachments/{attachmentId} [delete]
+func (s *AchievementService) DeleteAchievementAttachment(c *fiber.Ctx) error {
+	refID := c.Params("id")
+	attachmentID := attachmentIDParam(c)
+
+	role, userID, ok := getRoleAndUserID(c)
+	if !ok {
+		return c.SendStatus(fiber.StatusUnauthorized)
+	}
+
+	ref, err := s.resolveRef(role, userID, refID, getPermissions(c))
+	if err != nil {
+		return respondRefError(c, err)
+	}
+	if _, err := model.AchievementWorkflow.Fire(model.ActionEdit, ref.Status, role, model.TransitionContext{}); err != nil {
+		return respondTransitionError(c, err)
+	}
+
+	oid, err := primitive.ObjectIDFromHex(*ref.MongoID)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"message": "invalid mongo id"})
+	}
+
+	old, found, err := s.MongoRepo.RemoveAttachment(oid, attachmentID)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"message": "failed to delete attachment"})
+	}
+	if !found {
+		return c.Status(404).JSON(fiber.Map{"message": "attachment not found"})
+	}
+	s.deleteFiles(c.UserContext(), *old)
+
+	return c.JSON(fiber.Map{"message": "attachment deleted"})
+}
+
+// saveUpload validasi file lalu simpan ke storage. Lampiran belum tercatat di Mongo;
+// pemanggil wajib menghapus file jika pencatatan gagal.
+func (s *AchievementService) saveUpload(ctx context.Context, refID string, file *multipart.FileHeader) (*model.Attachment, error) {
+	src, err := file.Open()
+	if err != nil {
+		return nil, errAttachmentRead
+	}
+	defer src.Close()
+
+	contentType, err := s.Policy.Check(src, file.Size)
+	if err != nil {
+		return nil, err
+	}
+
+	name := sanitizeFilename(file.Filename, contentType)
+	key := path.Join("achievements", refID, uuid.New().String()+"_"+name)
+
+	result, err := s.Scanner.Scan(ctx, src)
+	if err != nil {
+		log.Println("scan attachment:", err)
+		return nil, errScannerUnavailable
+	}
+	if _, err := src.Seek(0, io.SeekStart); err != nil {
+		return nil, errAttachmentRead
+	}
+
+	// file terinfeksi disimpan di luar prefix achievements/ dan tidak pernah dicatat sebagai lampiran
+	if result.Infected {
+		qkey := path.Join(quarantinePrefix, key)
+		if err := s.Storage.Put(ctx, qkey, src, file.Size, contentType); err != nil {
+			log.Println("quarantine attachment:", err)
+		}
+		log.Printf("attachment quarantined: key=%s signature=%s", qkey, result.Signature)
+		return nil, fmt.Errorf("%w: %s", errAttachmentInfected, result.Signature)
+	}
+
+	if err := s.Storage.Put(ctx, key, src, file.Size, contentType); err != nil {
+		return nil, err
+	}
+
+	status := model.ScanStatusClean
+	if !result.Scanned {
+		status = model.ScanStatusUnscanned
+	}
+	attachment := &model.Attachment{
+		FileName:   name,
+		Key:        key,
+		FileType:   contentType,
+		ScanStatus: status,
+		UploadedAt: time.Now(),
+	}
+	attachment.ThumbnailKey = s.saveThumbnail(ctx, key, src, contentType)
+	return attachment, nil
+}
+
+// saveThumbnail buat thumbnail di key+".thumb.jpg". Gagal tidak membatalkan upload (lampiran tanpa thumbnail).
+func (s *AchievementService) saveThumbnail(ctx context.Context, key string, src io.ReadSeeker, contentType string) string {
+	if !thumbnailable(contentType) {
+		return ""
+	}
+	if _, err := src.Seek(0, io.SeekStart); err != nil {
+		return ""
+	}
+
+	thumb, err := generateThumbnail(src)
+	if err != nil {
+		log.Println("generate thumbnail:", err)
+		return ""
+	}
+	thumbKey := key + thumbnailSuffix
+	if err := s.Storage.Put(ctx, thumbKey, bytes.NewReader(thumb), int64(len(thumb)), "image/jpeg"); err != nil {
+		log.Println("save thumbnail:", err)
+		return ""
+	}
+	return thumbKey
+}
+
+func (s *AchievementService) respondUploadError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, errAttachmentUnsupported):
+		return c.Status(fiber.StatusUnsupportedMediaType).JSON(fiber.Map{
+			"message":      err.Error(),
+			"allowedTypes": s.Policy.AllowedTypes(),
+		})
+	case errors.Is(err, errAttachmentTooLarge):
+		return c.Status(fiber.StatusRequestEntityTooLarge).JSON(fiber.Map{"message": err.Error()})
+	case errors.Is(err, errAttachmentInfected):
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{"message": "file rejected: malware detected"})
+	case errors.Is(err, errScannerUnavailable):
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"message": err.Error()})
+	case errors.Is(err, errAttachmentRead):
+		return c.Status(400).JSON(fiber.Map{"message": "failed to read file"})
+	default:
+		return c.Status(500).JSON(fiber.Map{"message": "failed to save file"})
+	}
+}
+
+// deleteFiles hapus file lampiran beserta thumbnail-nya; kegagalan cukup di-log (sisa file dibersihkan reconcile)
+func (s *AchievementService) deleteFiles(ctx context.Context, a model.Attachment) {
+	for _, key := range []string{a.StorageKey(), a.ThumbnailKey} {
+		if key == "" {
+			continue
+		}
+		if err := s.Storage.Delete(ctx, key); err != nil {
+			log.Println("delete attachment file:", err)
+		}
+	}
+}
+
+//
+// ===== DOWNLOAD ATTACHMENT =====
+//
+
+// DownloadAchievementAttachment godoc
+// @Summary Download attachment
+// @Description Scope sama dengan detail: Admin semua, Mahasiswa miliknya, Dosen Wali mahasiswa bimbingan.
+// @Tags Achievements
+// @Security BearerAuth
+// @Produce octet-stream
+// @Param id path string true "Achievement ID"
+// @Param attachmentId path string true "Attachment ID"
+// @Success 200 {file} file
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /achievements/{id}/attachments/{attachmentId} [get]
+func (s *AchievementService) DownloadAchievementAttachment(c *fiber.Ctx) error {
+	role, userID, ok := getRoleAndUserID(c)
+	if !ok {
+		return c.SendStatus(fiber.StatusUnauthorized)
+	}
+	refID := c.Params("id")
+
+	ref, err := s.resolveRef(role, userID, refID, getPermissions(c))
+	if err != nil {
+		return respondRefError(c, err)
+	}
+	return s.sendAttachment(c, *ref.MongoID, attachmentIDParam(c), false)
+}
+
+// DownloadAchievementAttachmentThumbnail godoc
+// @Summary Download attachment thumbnail
+// @Description Thumbnail JPEG (sisi terpanjang maks 320px) untuk lampiran gambar. PDF belum punya preview.
+// @Tags Achievements
+// @Security BearerAuth
+// @Produce jpeg
+// @Param id path string true "Achievement ID"
+// @Param attachmentId path string true "Attachment ID"
+// @Success 200 {file} file
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /achievements/{id}/attachments/{attachmentId}/thumbnail [get]
+func (s *AchievementService) DownloadAchievementAttachmentThumbnail(c *fiber.Ctx) error {
+	role, userID, ok := getRoleAndUserID(c)
+	if !ok {
+		return c.SendStatus(fiber.StatusUnauthorized)
+	}
+	refID := c.Params("id")
+
+	ref, err := s.resolveRef(role, userID, refID, getPermissions(c))
+	if err != nil {
+		return respondRefError(c, err)
+	}
+	return s.sendAttachment(c, *ref.MongoID, attachmentIDParam(c), true)
+}
+
+// CreateAttachmentSignedURL godoc
+// @Summary Create short-lived signed URL for attachment
+// @Description URL bisa dibuka tanpa header Authorization (mis. <img>/<iframe>) sampai expiresAt.
+// @Tags Achievements
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Achievement ID"
+// @Param attachmentId path string true "Attachment ID"
+// @Param ttl query int false "Masa berlaku dalam detik (maks 3600)" default(300)
+// @Success 200 {object} model.AttachmentSignedURLResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /achievements/{id}/attachments/{attachmentId}/signed-url [post]
+func (s *AchievementService) CreateAttachmentSignedURL(c *fiber.Ctx) error {
+	role, userID, ok := getRoleAndUserID(c)
+	if !ok {
+		return c.SendStatus(fiber.StatusUnauthorized)
+	}
+	refID := c.Params("id")
+	attachmentID := attachmentIDParam(c)
+
+	ref, err := s.resolveRef(role, userID, refID, getPermissions(c))
+	if err != nil {
+		return respondRefError(c, err)
+	}
+	if _, err := s.findAttachment(*ref.MongoID, attachmentID); err != nil {
+		return respondAttachmentError(c, err)
+	}
+
+	ttl := time.Duration(c.QueryInt("ttl", int(signedURLDefaultTTL.Seconds()))) * time.Second
+	if ttl <= 0 || ttl > signedURLMaxTTL {
+		ttl = signedURLDefaultTTL
+	}
+	expires := time.Now().Add(ttl)
+
+	signedPath := attachmentDownloadPath(refID, attachmentID) + "/signed"
+	sig, err := utils.SignPath(signedPath, expires)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"message": "failed to sign url"})
+	}
+
+	return c.JSON(fiber.Map{
+		"data": fiber.Map{
+			"url":       signedPath + "?expires=" + strconv.FormatInt(expires.Unix(), 10) + "&signature=" + sig,
+			"expiresAt": expires.UTC().Format(time.RFC3339),
+		},
+	})
+}
+
+// DownloadSignedAttachment godoc
+// @Summary Download attachment via signed URL
+// @Description Tanpa JWT; hanya valid dengan expires + signature dari endpoint signed-url.
+// @Tags Achievements
+// @Produce octet-stream
+// @Param id path string true "Achievement ID"
+// @Param attachmentId path string true "Attachment ID"
+// @Param expires query int true "Unix timestamp kedaluwarsa"
+// @Param signature query string true "Signature"
+// @Success 200 {file} file
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /achievements/{id}/attachments/{attachmentId}/signed [get]
+func (s *AchievementService) DownloadSignedAttachment(c *fiber.Ctx) error {
+	refID := c.Params("id")
+	attachmentID := attachmentIDParam(c)
+
+	expires := int64(c.QueryInt("expires", 0))
+	signedPath := attachmentDownloadPath(refID, attachmentID) + "/signed"
+	if !utils.VerifyPathSignature(signedPath, expires, c.Query("signature")) {
+		return c.Status(403).JSON(fiber.Map{"message": "invalid or expired signature"})
+	}
+
+	// reference bisa saja sudah dihapus setelah URL dibuat
+	mongoID, _, found, err := s.Repo.GetRefForDetailAdmin(refID)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"message": "failed to fetch reference"})
+	}
+	if !found || mongoID == nil {
+		return c.Status(404).JSON(fiber.Map{"message": "achievement not found"})
+	}
+	return s.sendAttachment(c, *mongoID, attachmentID, false)
+}
+
+var (
+	errAttachmentNotFound = errors.New("attachment not found")
+	errAttachmentRead     = errors.New("failed to read file")
+	errAttachmentInfected = errors.New("attachment infected")
+	errScannerUnavailable = errors.New("virus scanner unavailable")
+)
+
+// prefix storage untuk file terinfeksi; tidak bisa diakses lewat endpoint download
+const quarantinePrefix = "quarantine"
+
+func (s *AchievementService) findAttachment(mongoID, attachmentID string) (*model.Attachment, error) {
+	oid, err := primitive.ObjectIDFromHex(mongoID)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := s.MongoRepo.FindByID(oid)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range doc.Attachments {
+		if a.AttachmentID() == attachmentID {
+			return &a, nil
+		}
+	}
+	return nil, errAttachmentNotFound
+}
+
+// sendAttachment stream file lampiran; thumbnail=true kirim thumbnail JPEG-nya
+func (s *AchievementService) sendAttachment(c *fiber.Ctx, mongoID, attachmentID string, thumbnail bool) error {
+	a, err := s.findAttachment(mongoID, attachmentID)
+	if err != nil {
+		return respondAttachmentError(c, err)
+	}
+
+	key, contentType, fileName := a.StorageKey(), a.FileType, a.FileName
+	if thumbnail {
+		if a.ThumbnailKey == "" {
+			return c.Status(404).JSON(fiber.Map{"message": "thumbnail not available"})
+		}
+		key, contentType = a.ThumbnailKey, "image/jpeg"
+		fileName = strings.TrimSuffix(fileName, path.Ext(fileName)) + thumbnailSuffix
+	}
+
+	body, info, err := s.Storage.Get(c.UserContext(), key)
+	if err != nil {
+		return respondAttachmentError(c, err)
+	}
+
+	if contentType == "" {
+		contentType = info.ContentType
+	}
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	c.Set(fiber.HeaderContentType, contentType)
+	c.Set(fiber.HeaderContentDisposition, mime.FormatMediaType("inline", map[string]string{"filename": fileName}))
+	c.Set(fiber.HeaderCacheControl, "private, no-store")
+	c.Set(fiber.HeaderXContentTypeOptions, "nosniff")
+	// body ditutup oleh fasthttp setelah stream selesai
+	return c.SendStream(body, int(info.Size))
+}
+
+func respondAttachmentLimit(c *fiber.Ctx, max int) error {
+	return c.Status(409).JSON(fiber.Map{"message": "attachment limit reached (max " + strconv.Itoa(max) + ")"})
+}
+
+func respondAttachmentError(c *fiber.Ctx, err error) error {
+	if errors.Is(err, errAttachmentNotFound) || errors.Is(err, storage.ErrNotFound) {
+		return c.Status(404).JSON(fiber.Map{"message": "attachment not found"})
+	}
+	return c.Status(500).JSON(fiber.Map{"message": "failed to fetch attachment"})
+}
+
+// resolveAttachmentURLs isi FileURL dengan endpoint download (butuh JWT + scope yang sama)
+func resolveAttachmentURLs(refID string, doc *model.AchievementMongo) {
+	for i := range doc.Attachments {
+		resolveAttachmentURL(refID, &doc.Attachments[i])
+	}
+}
+
+func resolveAttachmentURL(refID string, a *model.Attachment) {
+	a.ID = a.AttachmentID()
+	a.FileURL = attachmentDownloadPath(refID, a.ID)
+	if a.ThumbnailKey != "" {
+		a.ThumbnailURL = a.FileURL + "/thumbnail"
+	}
+}
+
+func attachmentDownloadPath(refID, attachmentID string) string {
+	return "/api/v1/achievements/" + refID + "/attachments/" + url.PathEscape(attachmentID)
+}
+
+// attachmentIDParam id lampiran dari path; id lama (fileName) bisa berisi karakter yang di-escape
+func attachmentIDParam(c *fiber.Ctx) string {
+	id := c.Params("attachmentId")
+	if u, err := url.PathUnescape(id); err == nil {
+		return u
+	}
+	return id
+}
@@ -0,0 +1,288 @@
+package service
+
+import (
+	"errors"
+	"strings"
+
+	"project_uas/app/model"
+	"project_uas/app/repository"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const bulkMaxItems = 100
+
+var errPointsCalculation = errors.New("failed to calculate points")
+
+//
+// ===== BULK VERIFY / REJECT =====
+//
+
+// BulkVerifyAchievements godoc
+// @Summary Bulk verify achievements (submitted -> verified / awaiting_approval)
+// @Description Maks 100 id. Status akhir per item mengikuti aturan approval-stages (lihat verify). Semua diproses dalam satu transaksi; item yang gagal tidak membatalkan item lain,
+// @Description kecuali atomic=true (body atau query) -> satu gagal, semua dibatalkan (409).
+// @Tags Achievements
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param body body model.BulkVerifyRequest true "Daftar id"
+// @Param atomic query bool false "Semua atau tidak sama sekali"
+// @Success 200 {object} model.BulkActionResponse
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 409 {object} model.BulkActionResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /achievements/bulk/verify [post]
+func (s *AchievementService) BulkVerifyAchievements(c *fiber.Ctx) error {
+	role, userID, ok := getRoleAndUserID(c)
+	if !ok {
+		return c.SendStatus(fiber.StatusUnauthorized)
+	}
+
+	var body model.BulkVerifyRequest
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(400).JSON(fiber.Map{"message": "invalid body"})
+	}
+	ids, err := normalizeBulkIDs(body.IDs)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"message": err.Error()})
+	}
+	if _, err := model.AchievementWorkflow.Fire(model.ActionVerify, model.StatusSubmitted, role, model.TransitionContext{}); err != nil {
+		return respondTransitionError(c, err)
+	}
+
+	report := newBulkReport("verify", ids, body.Atomic || c.QueryBool("atomic"))
+
+	// cek scope, status, dan hitung poin sebelum transaksi
+	perms := getPermissions(c)
+	var items []model.BulkVerifyItem
+	var idx []int
+	for i, id := range ids {
+		ref, err := s.resolveRef(role, userID, id, perms)
+		if err == nil {
+			_, err = model.AchievementWorkflow.Fire(model.ActionVerify, ref.Status, role, model.TransitionContext{})
+		}
+		if err != nil {
+			report.fail(i, err)
+			continue
+		}
+		plan, err := s.planVerification(id, *ref.MongoID)
+		if err != nil {
+			if !errors.Is(err, errDetailPending) && !errors.Is(err, errDetailUnavailable) {
+				err = errPointsCalculation
+			}
+			report.fail(i, err)
+			continue
+		}
+		report.Results[i].Points = &plan.Points
+		report.Results[i].Status = model.StatusVerified
+		if len(plan.Stages) > 0 {
+			report.Results[i].Status = model.StatusAwaitingApproval
+		}
+		items = append(items, model.BulkVerifyItem{ID: id, Points: plan.Points, Stages: plan.Stages})
+		idx = append(idx, i)
+	}
+
+	if len(items) > 0 && !(report.Atomic && report.Failed > 0) {
+		errs, committed, err := s.Repo.BulkVerify(items, userID, report.Atomic)
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{"message": "cannot verify"})
+		}
+		report.apply(idx, errs, committed, "")
+	}
+	report.finish()
+
+	for _, r := range report.Results {
+		if r.Result != model.BulkItemSucceeded {
+			continue
+		}
+		switch r.Status {
+		case model.StatusVerified:
+			s.Outbox.Dispatch(r.ID)
+			s.publish(model.EventAchievementVerified, r.ID, userID, model.StatusVerified, "")
+		case model.StatusAwaitingApproval:
+			s.publish(model.EventAchievementAwaitingApproval, r.ID, userID, model.StatusAwaitingApproval, "")
+		}
+	}
+	return respondBulkReport(c, report)
+}
+
+// BulkRejectAchievements godoc
+// @Summary Bulk reject achievements (submitted -> rejected)
+// @Description Maks 100 id, note dipakai untuk semua item. Aturan transaksi sama dengan bulk verify.
+// @Tags Achievements
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param body body model.BulkRejectRequest true "Daftar id + note"
+// @Param atomic query bool false "Semua atau tidak sama sekali"
+// @Success 200 {object} model.BulkActionResponse
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 409 {object} model.BulkActionResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /achievements/bulk/reject [post]
+func (s *AchievementService) BulkRejectAchievements(c *fiber.Ctx) error {
+	role, userID, ok := getRoleAndUserID(c)
+	if !ok {
+		return c.SendStatus(fiber.StatusUnauthorized)
+	}
+
+	var body model.BulkRejectRequest
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(400).JSON(fiber.Map{"message": "invalid body"})
+	}
+	body.Note = strings.TrimSpace(body.Note)
+	ids, err := normalizeBulkIDs(body.IDs)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"message": err.Error()})
+	}
+	tctx := model.TransitionContext{Note: body.Note}
+	if _, err := model.AchievementWorkflow.Fire(model.ActionReject, model.StatusSubmitted, role, tctx); err != nil {
+		return respondTransitionError(c, err)
+	}
+
+	report := newBulkReport("reject", ids, body.Atomic || c.QueryBool("atomic"))
+
+	perms := getPermissions(c)
+	var pending []string
+	var idx []int
+	for i, id := range ids {
+		ref, err := s.resolveRef(role, userID, id, perms)
+		if err == nil {
+			_, err = model.AchievementWorkflow.Fire(model.ActionReject, ref.Status, role, tctx)
+		}
+		if err != nil {
+			report.fail(i, err)
+			continue
+		}
+		pending = append(pending, id)
+		idx = append(idx, i)
+	}
+
+	if len(pending) > 0 && !(report.Atomic && report.Failed > 0) {
+		errs, committed, err := s.Repo.BulkReject(pending, body.Note, userID, report.Atomic)
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{"message": "cannot reject"})
+		}
+		report.apply(idx, errs, committed, model.StatusRejected)
+	}
+	report.finish()
+
+	for _, r := range report.Results {
+		if r.Result == model.BulkItemSucceeded {
+			s.publish(model.EventAchievementRejected, r.ID, userID, model.StatusRejected, body.Note)
+		}
+	}
+
+	return respondBulkReport(c, report)
+}
+
+// normalizeBulkIDs trim + buang duplikat, urutan dipertahankan
+func normalizeBulkIDs(raw []string) ([]string, error) {
+	seen := make(map[string]bool, len(raw))
+	ids := make([]string, 0, len(raw))
+	for _, id := range raw {
+		id = strings.TrimSpace(id)
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		return nil, errors.New("ids is required")
+	}
+	if len(ids) > bulkMaxItems {
+		return nil, errors.New("too many ids (max 100)")
+	}
+	return ids, nil
+}
+
+type bulkReport struct {
+	model.BulkActionReport
+}
+
+func newBulkReport(action string, ids []string, atomic bool) *bulkReport {
+	r := &bulkReport{model.BulkActionReport{Action: action, Atomic: atomic, Results: make([]model.BulkItemResult, len(ids))}}
+	for i, id := range ids {
+		r.Results[i] = model.BulkItemResult{ID: id}
+	}
+	return r
+}
+
+// fail tandai item gagal dengan kode ala HTTP sesuai jenis error
+func (r *bulkReport) fail(i int, err error) {
+	code, msg := fiber.StatusInternalServerError, "internal error"
+
+	var te *model.TransitionError
+	switch {
+	case errors.As(err, &te):
+		code, msg = fiber.StatusConflict, te.Error()
+		if errors.Is(err, model.ErrRoleNotAllowed) {
+			code = fiber.StatusForbidden
+		}
+	case errors.Is(err, repository.ErrNotFoundOrForbidden):
+		code, msg = fiber.StatusNotFound, "achievement not found"
+	case errors.Is(err, model.ErrRoleNotAllowed):
+		code, msg = fiber.StatusForbidden, err.Error()
+	case errors.Is(err, errPointsCalculation):
+		msg = err.Error()
+	case errors.Is(err, errDetailPending):
+		code, msg = fiber.StatusConflict, err.Error()
+	case errors.Is(err, errDetailUnavailable):
+		code, msg = fiber.StatusServiceUnavailable, errDetailUnavailable.Error()
+	}
+
+	r.Results[i].Result = model.BulkItemFailed
+	r.Results[i].Code = code
+	r.Results[i].Message = &msg
+	r.Results[i].Status = ""
+	r.Results[i].Points = nil
+	r.Failed++
+}
+
+// apply petakan hasil repository (errs sejajar dengan idx) ke laporan.
+// status kosong = pakai status yang sudah diisi per item.
+func (r *bulkReport) apply(idx []int, errs []error, committed bool, status string) {
+	for j, i := range idx {
+		if errs[j] != nil {
+			r.fail(i, errs[j])
+			continue
+		}
+		if committed {
+			r.Results[i].Result = model.BulkItemSucceeded
+			if status != "" {
+				r.Results[i].Status = status
+			}
+			r.Results[i].Code = fiber.StatusOK
+		}
+	}
+	r.Committed = committed
+}
+
+// finish item yang tidak gagal tapi juga tidak tersimpan -> rolled_back (mode atomic)
+func (r *bulkReport) finish() {
+	for i := range r.Results {
+		if r.Results[i].Result == "" {
+			r.Results[i].Result = model.BulkItemRolledBack
+			r.Results[i].Code = fiber.StatusConflict
+			r.Results[i].Status = ""
+			r.Results[i].Points = nil
+		}
+		if r.Results[i].Result == model.BulkItemSucceeded {
+			r.Succeeded++
+		}
+	}
+}
+
+func respondBulkReport(c *fiber.Ctx, r *bulkReport) error {
+	status := fiber.StatusOK
+	if r.Atomic && !r.Committed {
+		status = fiber.StatusConflict
+	}
+	return c.Status(status).JSON(fiber.Map{"data": r.BulkActionReport})
+}
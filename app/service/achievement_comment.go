@@ -0,0 +1,148 @@
+package service
+
+import (
+	"errors"
+	"strings"
+
+	"project_uas/app/model"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+//
+// ===== COMMENTS =====
+// Thread diskusi per prestasi: mahasiswa pemilik, dosen wali (termasuk pengganti selama delegasi), dan admin.
+//
+
+// commentAllowed role yang boleh membaca / menulis komentar; scope prestasi tetap lewat resolveRef
+func commentAllowed(role string) bool {
+	return role == model.RoleAdmin || role == model.RoleStudent || role == model.RoleLecturer
+}
+
+// GetAchievementComments godoc
+// @Summary List achievement comments
+// @Description Thread diskusi prestasi, urut dari yang paling lama.
+// @Tags Achievements
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Achievement ID"
+// @Success 200 {object} model.AchievementCommentListResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /achievements/{id}/comments [get]
+func (s *AchievementService) GetAchievementComments(c *fiber.Ctx) error {
+	role, userID, ok := getRoleAndUserID(c)
+	if !ok {
+		return c.SendStatus(fiber.StatusUnauthorized)
+	}
+	if !commentAllowed(role) {
+		return c.SendStatus(fiber.StatusForbidden)
+	}
+	refID := c.Params("id")
+
+	if _, err := s.resolveRef(role, userID, refID, getPermissions(c)); err != nil {
+		return respondRefError(c, err)
+	}
+
+	comments, err := s.CommentRepo.ListByAchievement(refID)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"message": "failed to fetch comments"})
+	}
+	return c.JSON(fiber.Map{"data": comments})
+}
+
+// CreateAchievementComment godoc
+// @Summary Add achievement comment
+// @Description Bisa di semua status (kecuali deleted). attachmentId opsional untuk menunjuk lampiran yang dibahas.
+// @Tags Achievements
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Achievement ID"
+// @Param body body model.AchievementCommentRequest true "Komentar"
+// @Success 201 {object} model.AchievementCommentResponse
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 422 {object} model.ValidationErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /achievements/{id}/comments [post]
+func (s *AchievementService) CreateAchievementComment(c *fiber.Ctx) error {
+	role, userID, ok := getRoleAndUserID(c)
+	if !ok {
+		return c.SendStatus(fiber.StatusUnauthorized)
+	}
+	if !commentAllowed(role) {
+		return c.SendStatus(fiber.StatusForbidden)
+	}
+	refID := c.Params("id")
+
+	var body model.AchievementCommentRequest
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(400).JSON(fiber.Map{"message": "invalid body"})
+	}
+	if errs := body.Validate(); len(errs) > 0 {
+		return respondValidationError(c, errs)
+	}
+	body.Body = strings.TrimSpace(body.Body)
+
+	ref, err := s.resolveRef(role, userID, refID, getPermissions(c))
+	if err != nil {
+		return respondRefError(c, err)
+	}
+
+	if body.AttachmentID != nil {
+		id := strings.TrimSpace(*body.AttachmentID)
+		if _, err := s.findAttachment(*ref.MongoID, id); err != nil {
+			if errors.Is(err, errAttachmentNotFound) {
+				return respondValidationError(c, []model.FieldError{{Field: "attachmentId", Message: "attachment not found"}})
+			}
+			return c.Status(500).JSON(fiber.Map{"message": "failed to fetch attachment"})
+		}
+		body.AttachmentID = &id
+	}
+
+	comment, err := s.CommentRepo.Create(refID, userID, body.Body, body.AttachmentID)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"message": "failed to create comment"})
+	}
+	s.Events.Publish(model.AchievementEvent{
+		Type:          model.EventAchievementCommented,
+		AchievementID: refID,
+		ActorUserID:   userID,
+		Status:        ref.Status,
+		Note:          comment.Body,
+		CommentID:     comment.ID,
+	})
+	return c.Status(201).JSON(fiber.Map{"data": comment})
+}
+
+// mergeTimeline sisipkan komentar ke history (keduanya sudah urut waktu). Status komentar =
+// status prestasi saat komentar ditulis; pada waktu yang sama transisi didahulukan.
+func mergeTimeline(history []model.AchievementHistory, comments []model.AchievementComment) []model.AchievementHistory {
+	out := make([]model.AchievementHistory, 0, len(history)+len(comments))
+	status := ""
+	i := 0
+	for j := range comments {
+		cm := &comments[j]
+		for i < len(history) && !history[i].At.After(cm.CreatedAt) {
+			status = history[i].Status
+			out = append(out, history[i])
+			i++
+		}
+		out = append(out, model.AchievementHistory{
+			Type:      model.HistoryComment,
+			ID:        cm.ID,
+			Action:    model.HistoryComment,
+			Status:    status,
+			ActorID:   &cm.AuthorID,
+			ActorName: &cm.AuthorName,
+			At:        cm.CreatedAt,
+			Comment:   cm,
+		})
+	}
+	return append(out, history[i:]...)
+}
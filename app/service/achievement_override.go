@@ -0,0 +1,121 @@
+package service
+
+import (
+	"strings"
+
+	"project_uas/app/model"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+//
+// ===== ADMIN OVERRIDE =====
+// Admin memutuskan atas nama dosen wali (mis. dosen cuti). Tercatat di history sebagai
+// override_verify / override_reject beserta justification dan dosen wali yang di-override.
+//
+
+// OverrideVerifyAchievement godoc
+// @Summary Admin override: verify achievement (submitted -> verified / awaiting_approval)
+// @Description Wajib justification. Poin dan tahap persetujuan dihitung sama seperti verify biasa;
+// @Description override hanya menggantikan dosen wali, tahap berikutnya tetap harus disetujui.
+// @Tags Achievements
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Achievement ID"
+// @Param body body model.OverrideVerifyRequest true "Justification"
+// @Success 200 {object} model.MessageResponse
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 409 {object} model.TransitionErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Failure 503 {object} model.ErrorResponse
+// @Router /achievements/{id}/override/verify [post]
+func (s *AchievementService) OverrideVerifyAchievement(c *fiber.Ctx) error {
+	role, userID, ok := getRoleAndUserID(c)
+	if !ok {
+		return c.SendStatus(fiber.StatusUnauthorized)
+	}
+	refID := c.Params("id")
+
+	var body model.OverrideVerifyRequest
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(400).JSON(fiber.Map{"message": "justification is required"})
+	}
+	body.Justification = strings.TrimSpace(body.Justification)
+
+	ref, err := s.resolveRef(role, userID, refID, getPermissions(c))
+	if err != nil {
+		return respondRefError(c, err)
+	}
+	tctx := model.TransitionContext{Justification: body.Justification}
+	if _, err := model.AchievementWorkflow.Fire(model.ActionOverrideVerify, ref.Status, role, tctx); err != nil {
+		return respondTransitionError(c, err)
+	}
+
+	plan, err := s.planVerification(refID, *ref.MongoID)
+	if err != nil {
+		return respondPlanError(c, err)
+	}
+
+	if err := s.Repo.OverrideVerify(refID, userID, plan.Points, plan.Stages, body.Justification); err != nil {
+		return respondActionError(c, err, "cannot verify")
+	}
+	if len(plan.Stages) > 0 {
+		s.publish(model.EventAchievementAwaitingApproval, refID, userID, model.StatusAwaitingApproval, "")
+		return c.JSON(fiber.Map{"message": "achievement verified by admin override, awaiting approval"})
+	}
+	s.Outbox.Dispatch(refID)
+	s.publish(model.EventAchievementVerified, refID, userID, model.StatusVerified, "")
+
+	return c.JSON(fiber.Map{"message": "achievement verified by admin override"})
+}
+
+// OverrideRejectAchievement godoc
+// @Summary Admin override: reject achievement (submitted -> rejected)
+// @Description Wajib note (untuk mahasiswa) dan justification (alasan override).
+// @Tags Achievements
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Achievement ID"
+// @Param body body model.OverrideRejectRequest true "Note + justification"
+// @Success 200 {object} model.MessageResponse
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 409 {object} model.TransitionErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /achievements/{id}/override/reject [post]
+func (s *AchievementService) OverrideRejectAchievement(c *fiber.Ctx) error {
+	role, userID, ok := getRoleAndUserID(c)
+	if !ok {
+		return c.SendStatus(fiber.StatusUnauthorized)
+	}
+	refID := c.Params("id")
+
+	var body model.OverrideRejectRequest
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(400).JSON(fiber.Map{"message": "note and justification are required"})
+	}
+	body.Note = strings.TrimSpace(body.Note)
+	body.Justification = strings.TrimSpace(body.Justification)
+
+	ref, err := s.resolveRef(role, userID, refID, getPermissions(c))
+	if err != nil {
+		return respondRefError(c, err)
+	}
+	tctx := model.TransitionContext{Note: body.Note, Justification: body.Justification}
+	if _, err := model.AchievementWorkflow.Fire(model.ActionOverrideReject, ref.Status, role, tctx); err != nil {
+		return respondTransitionError(c, err)
+	}
+
+	if err := s.Repo.OverrideReject(refID, body.Note, body.Justification, userID); err != nil {
+		return respondActionError(c, err, "cannot reject")
+	}
+	s.publish(model.EventAchievementRejected, refID, userID, model.StatusRejected, body.Note)
+	return c.JSON(fiber.Map{"message": "achievement rejected by admin override"})
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"time"
+
+	"project_uas/app/model"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// VerificationSLA batas waktu verifikasi sejak submit.
+// VERIFICATION_SLA_DAYS (default 7) dan VERIFICATION_SLA_WARNING_DAYS (default 5).
+type VerificationSLA struct {
+	WarningDays int
+	DueDays     int
+}
+
+func VerificationSLAFromEnv() VerificationSLA {
+	sla := VerificationSLA{
+		WarningDays: envInt("VERIFICATION_SLA_WARNING_DAYS", 5),
+		DueDays:     envInt("VERIFICATION_SLA_DAYS", 7),
+	}
+	if sla.WarningDays > sla.DueDays {
+		sla.WarningDays = sla.DueDays
+	}
+	return sla
+}
+
+func (sla VerificationSLA) warning() time.Duration {
+	return time.Duration(sla.WarningDays) * 24 * time.Hour
+}
+
+func (sla VerificationSLA) due() time.Duration {
+	return time.Duration(sla.DueDays) * 24 * time.Hour
+}
+
+func (sla VerificationSLA) status(waiting time.Duration) string {
+	switch {
+	case waiting >= sla.due():
+		return model.SLAOverdue
+	case waiting >= sla.warning():
+		return model.SLAWarning
+	default:
+		return model.SLAOnTrack
+	}
+}
+
+// GetVerificationQueue godoc
+// @Summary Verification queue (Dosen Wali)
+// @Description Prestasi submitted milik mahasiswa bimbingan (termasuk delegasi aktif dari dosen lain), urut dari yang paling lama menunggu.
+// @Description Tiap item berisi title/type/points (Mongo), jumlah lampiran, dan status SLA (on_track | warning | overdue).
+// @Tags Achievements
+// @Security BearerAuth
+// @Produce json
+// @Param limit query int false "Limit (maks 100)" default(50)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} model.AchievementQueue
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /achievements/queue [get]
+func (s *AchievementService) GetVerificationQueue(c *fiber.Ctx) error {
+	role, userID, ok := getRoleAndUserID(c)
+	if !ok {
+		return c.SendStatus(fiber.StatusUnauthorized)
+	}
+	if role != model.RoleLecturer {
+		return c.SendStatus(fiber.StatusForbidden)
+	}
+
+	limit := c.QueryInt("limit", 50)
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+	offset := c.QueryInt("offset", 0)
+	if offset < 0 {
+		offset = 0
+	}
+
+	now := time.Now()
+	items, meta, err := s.Repo.ListQueue(userID, now.Add(-s.SLA.warning()), now.Add(-s.SLA.due()), limit, offset)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"message": "failed to fetch verification queue"})
+	}
+
+	ids := make([]primitive.ObjectID, 0, len(items))
+	for _, it := range items {
+		if oid, err := primitive.ObjectIDFromHex(it.MongoAchievementID); err == nil {
+			ids = append(ids, oid)
+		}
+	}
+	docs, err := s.MongoRepo.FindSummaries(ids)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"message": "failed to fetch achievement details"})
+	}
+
+	meta.SLADays, meta.WarnDays = s.SLA.DueDays, s.SLA.WarningDays
+	queue := model.AchievementQueue{Data: make([]model.QueueItem, 0, len(items)), Meta: meta}
+	for _, q := range items {
+		if oid, err := primitive.ObjectIDFromHex(q.MongoAchievementID); err == nil {
+			if doc, ok := docs[oid]; ok {
+				q.Title = doc.Title
+				q.AchievementType = doc.AchievementType
+				q.Points = doc.Points
+				q.Tags = doc.Tags
+				q.AttachmentCount = len(doc.Attachments)
+			}
+		}
+
+		since := q.CreatedAt
+		if q.SubmittedAt != nil {
+			since = *q.SubmittedAt
+		}
+		waiting := now.Sub(since)
+		q.WaitingHours = int(waiting.Hours())
+		q.SLADueAt = since.Add(s.SLA.due())
+		q.SLAStatus = s.SLA.status(waiting)
+		queue.Data = append(queue.Data, q)
+	}
+
+	return c.JSON(queue)
+}
@@ -0,0 +1,214 @@
+package service
+
+import (
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"project_uas/app/model"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const snippetRadius = 80
+
+// SearchAchievements godoc
+// @Summary Full-text search achievements
+// @Description Cari di title, description, tags, dan isi details (MongoDB text index), urut relevansi.
+// @Description Scope sama dengan GET /achievements (Admin semua, Mahasiswa miliknya, Dosen Wali mahasiswa bimbingan).
+// @Description Highlight berupa potongan teks (HTML-escaped) dengan kata yang cocok dibungkus <mark>.
+// @Tags Achievements
+// @Security BearerAuth
+// @Produce json
+// @Param q query string true "Kata kunci"
+// @Param limit query int false "Limit (maks 50)" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} model.AchievementSearchResponse
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /achievements/search [get]
+func (s *AchievementService) SearchAchievements(c *fiber.Ctx) error {
+	role, userID, ok := getRoleAndUserID(c)
+	if !ok {
+		return c.SendStatus(fiber.StatusUnauthorized)
+	}
+
+	q := strings.TrimSpace(c.Query("q"))
+	if q == "" {
+		return c.Status(400).JSON(fiber.Map{"message": "q is required"})
+	}
+	limit := c.QueryInt("limit", 20)
+	if limit <= 0 || limit > 50 {
+		limit = 20
+	}
+	offset := c.QueryInt("offset", 0)
+	if offset < 0 {
+		offset = 0
+	}
+
+	// batasi di Mongo dulu supaya limit tidak terpakai dokumen di luar scope
+	var studentIDs []string
+	switch role {
+	case model.RoleAdmin:
+	case model.RoleStudent:
+		studentID, found, err := s.Repo.GetStudentIDByUserID(userID)
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{"message": "failed to resolve student"})
+		}
+		if !found {
+			return c.Status(404).JSON(fiber.Map{"message": "student not found"})
+		}
+		studentIDs = []string{studentID}
+	case model.RoleLecturer:
+		ids, err := s.Repo.GetAdviseeStudentIDs(userID)
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{"message": "failed to load advisees"})
+		}
+		studentIDs = ids
+	default:
+		return c.SendStatus(fiber.StatusForbidden)
+	}
+
+	docs, scores, err := s.MongoRepo.Search(q, studentIDs, limit, offset)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"message": "failed to search achievements"})
+	}
+
+	refIDs := make([]string, 0, len(docs))
+	for _, d := range docs {
+		refIDs = append(refIDs, d.AchievementRefID)
+	}
+
+	// scope + status (non-deleted) tetap ditegakkan oleh Postgres, sama seperti list
+	page, err := s.Repo.List(model.AchievementScope{Role: role, UserID: userID}, model.AchievementFilter{
+		RefIDs: refIDs,
+		Limit:  100,
+	})
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"message": "failed to search achievements"})
+	}
+	refs := make(map[string]model.AchievementListItem, len(page.Data))
+	for _, it := range page.Data {
+		refs[it.ID] = it
+	}
+
+	terms := searchTerms(q)
+	results := []model.AchievementSearchResult{}
+	for i, d := range docs {
+		item, ok := refs[d.AchievementRefID]
+		if !ok {
+			continue
+		}
+		item.Title = d.Title
+		item.AchievementType = d.AchievementType
+		item.Points = d.Points
+		item.Tags = d.Tags
+
+		results = append(results, model.AchievementSearchResult{
+			AchievementListItem: item,
+			Score:               scores[i],
+			Highlights:          highlightDocument(d, terms),
+		})
+	}
+
+	return c.JSON(fiber.Map{"data": results})
+}
+
+// searchTerms pecah query jadi kata (tanpa tanda kutip dan kata negasi "-xxx").
+func searchTerms(q string) []string {
+	var out []string
+	for _, f := range strings.Fields(strings.ReplaceAll(q, `"`, " ")) {
+		if strings.HasPrefix(f, "-") {
+			continue
+		}
+		out = append(out, strings.ToLower(f))
+	}
+	return out
+}
+
+func highlightDocument(d model.AchievementMongo, terms []string) map[string]string {
+	out := map[string]string{}
+	add := func(field, text string) {
+		if snip, ok := highlight(text, terms); ok {
+			out[field] = snip
+		}
+	}
+
+	add("title", d.Title)
+	add("description", d.Description)
+	add("tags", strings.Join(d.Tags, ", "))
+
+	keys := make([]string, 0, len(d.Details))
+	for k := range d.Details {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		add("details."+k, fmt.Sprint(d.Details[k]))
+	}
+	return out
+}
+
+// highlight ambil potongan teks di sekitar kecocokan pertama dan tandai semua term dengan <mark>.
+func highlight(text string, terms []string) (string, bool) {
+	lower := strings.ToLower(text)
+	if len(lower) != len(text) {
+		// lowercase mengubah panjang byte (unicode tertentu): pakai teks asli supaya index tetap sejajar
+		lower = text
+	}
+	first := -1
+	for _, t := range terms {
+		if i := strings.Index(lower, t); i >= 0 && (first < 0 || i < first) {
+			first = i
+		}
+	}
+	if first < 0 {
+		return "", false
+	}
+
+	start, end := first-snippetRadius, first+snippetRadius
+	if start < 0 {
+		start = 0
+	}
+	if end > len(text) {
+		end = len(text)
+	}
+	for start > 0 && !utf8.RuneStart(text[start]) {
+		start--
+	}
+	for end < len(text) && !utf8.RuneStart(text[end]) {
+		end++
+	}
+
+	snippet := text[start:end]
+	snippetLower := lower[start:end]
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("…")
+	}
+	for i := 0; i < len(snippet); {
+		matched := ""
+		for _, t := range terms {
+			if t != "" && strings.HasPrefix(snippetLower[i:], t) && len(t) > len(matched) {
+				matched = t
+			}
+		}
+		if matched != "" {
+			b.WriteString("<mark>" + html.EscapeString(snippet[i:i+len(matched)]) + "</mark>")
+			i += len(matched)
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(snippet[i:])
+		b.WriteString(html.EscapeString(snippet[i : i+size]))
+		i += size
+	}
+	if end < len(text) {
+		b.WriteString("…")
+	}
+	return b.String(), true
+}
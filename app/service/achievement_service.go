@@ -1,26 +1,41 @@
 package service
 
 import (
-	"os"
-	"path/filepath"
+	"errors"
+	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"project_uas/app/model"
 	"project_uas/app/repository"
+	"project_uas/scanner"
+	"project_uas/storage"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type AchievementService struct {
-	Repo      repository.AchievementRepository
-	MongoRepo repository.AchievementMongoRepository
+	Repo        repository.AchievementRepository
+	MongoRepo   repository.AchievementMongoRepository
+	TypeRepo    repository.AchievementTypeRepository
+	StageRepo   repository.ApprovalStageRepository
+	CommentRepo repository.AchievementCommentRepository
+	Storage     storage.Storage
+	Scanner     scanner.Scanner
+	Policy      AttachmentPolicy
+	SLA         VerificationSLA
+	Outbox      *OutboxProcessor
+	// event alur prestasi (notifikasi dsb.), dikirim async setelah commit
+	Events *EventBus
 }
 
-func NewAchievementService(repo repository.AchievementRepository, mongoRepo repository.AchievementMongoRepository) *AchievementService {
-	return &AchievementService{Repo: repo, MongoRepo: mongoRepo}
+func NewAchievementService(repo repository.AchievementRepository, mongoRepo repository.AchievementMongoRepository, typeRepo repository.AchievementTypeRepository, stageRepo repository.ApprovalStageRepository, commentRepo repository.AchievementCommentRepository, store storage.Storage, scan scanner.Scanner, policy AttachmentPolicy, sla VerificationSLA, outbox *OutboxProcessor, events *EventBus) *AchievementService {
+	return &AchievementService{Repo: repo, MongoRepo: mongoRepo, TypeRepo: typeRepo, StageRepo: stageRepo, CommentRepo: commentRepo, Storage: store, Scanner: scan, Policy: policy, SLA: sla, Outbox: outbox, Events: events}
 }
 
 func getRoleAndUserID(c *fiber.Ctx) (role, userID string, ok bool) {
@@ -51,10 +66,25 @@ func getRoleAndUserID(c *fiber.Ctx) (role, userID string, ok bool) {
 // GetAchievements godoc
 // @Summary List achievements
 // @Description Admin: semua. Mahasiswa: miliknya. Dosen Wali: mahasiswa bimbingan.
+// @Description Tiap item sudah berisi title/type/points/tags (Mongo) serta nama & NIM mahasiswa.
+// @Description Mendukung filter, sort, dan cursor pagination (pakai meta.nextCursor untuk halaman berikutnya).
 // @Tags Achievements
 // @Security BearerAuth
 // @Produce json
+// @Param status query string false "Filter status, pisahkan dengan koma (draft,submitted,...)"
+// @Param type query string false "Filter achievementType"
+// @Param student query string false "Filter student (students.id atau NIM)"
+// @Param from query string false "created_at >= (RFC3339 atau YYYY-MM-DD)"
+// @Param to query string false "created_at <= (RFC3339 atau YYYY-MM-DD)"
+// @Param tags query string false "Harus punya semua tag, pisahkan dengan koma"
+// @Param minPoints query int false "Points minimal"
+// @Param maxPoints query int false "Points maksimal"
+// @Param sort query string false "created_at | updated_at | submitted_at" default(created_at)
+// @Param order query string false "asc | desc" default(desc)
+// @Param limit query int false "Limit (maks 100)" default(20)
+// @Param cursor query string false "Cursor dari meta.nextCursor (hanya berlaku untuk sort, order, dan filter yang sama)"
 // @Success 200 {object} model.AchievementListResponse
+// @Failure 400 {object} model.ErrorResponse
 // @Failure 401 {object} model.ErrorResponse
 // @Failure 403 {object} model.ErrorResponse
 // @Failure 500 {object} model.ErrorResponse
@@ -64,27 +94,137 @@ func (s *AchievementService) GetAchievements(c *fiber.Ctx) error {
 	if !ok {
 		return c.SendStatus(fiber.StatusUnauthorized)
 	}
+	if role != model.RoleAdmin && role != model.RoleStudent && role != model.RoleLecturer {
+		return c.SendStatus(fiber.StatusForbidden)
+	}
+
+	filter, err := parseAchievementFilter(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"message": err.Error()})
+	}
+
+	page, err := s.Repo.List(model.AchievementScope{Role: role, UserID: userID}, filter)
+	if errors.Is(err, repository.ErrInvalidCursor) {
+		return c.Status(400).JSON(fiber.Map{"message": "invalid cursor"})
+	}
+	if errors.Is(err, repository.ErrCursorMismatch) {
+		return c.Status(400).JSON(fiber.Map{"message": err.Error()})
+	}
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"message": "failed to fetch achievements"})
+	}
+
+	if err := s.enrichListItems(page.Data); err != nil {
+		return c.Status(500).JSON(fiber.Map{"message": "failed to fetch achievement details"})
+	}
+	return c.JSON(page)
+}
 
-	var (
-		data interface{}
-		err  error
-	)
+// enrichListItems isi title/type/points/tags dari Mongo dengan satu query $in per halaman.
+func (s *AchievementService) enrichListItems(items []model.AchievementListItem) error {
+	ids := make([]primitive.ObjectID, 0, len(items))
+	for _, it := range items {
+		if oid, err := primitive.ObjectIDFromHex(it.MongoAchievementID); err == nil {
+			ids = append(ids, oid)
+		}
+	}
 
-	switch role {
-	case "Admin":
-		data, err = s.Repo.GetAll()
-	case "Mahasiswa":
-		data, err = s.Repo.GetByStudent(userID)
-	case "Dosen Wali":
-		data, err = s.Repo.GetBySupervisor(userID)
+	docs, err := s.MongoRepo.FindSummaries(ids)
+	if err != nil {
+		return err
+	}
+
+	for i := range items {
+		oid, err := primitive.ObjectIDFromHex(items[i].MongoAchievementID)
+		if err != nil {
+			continue
+		}
+		doc, ok := docs[oid]
+		if !ok {
+			continue
+		}
+		items[i].Title = doc.Title
+		items[i].AchievementType = doc.AchievementType
+		items[i].Points = doc.Points
+		items[i].Tags = doc.Tags
+	}
+	return nil
+}
+
+func parseAchievementFilter(c *fiber.Ctx) (model.AchievementFilter, error) {
+	f := model.AchievementFilter{
+		Status:          splitCSV(c.Query("status")),
+		AchievementType: strings.TrimSpace(c.Query("type")),
+		Student:         strings.TrimSpace(c.Query("student")),
+		Tags:            splitCSV(c.Query("tags")),
+		Sort:            c.Query("sort", "created_at"),
+		Order:           strings.ToLower(c.Query("order", "desc")),
+		Limit:           c.QueryInt("limit", 20),
+		Cursor:          c.Query("cursor"),
+	}
+
+	if f.Order != "asc" && f.Order != "desc" {
+		return f, errors.New("order must be asc or desc")
+	}
+	switch f.Sort {
+	case "created_at", "updated_at", "submitted_at":
 	default:
-		return c.SendStatus(fiber.StatusForbidden)
+		return f, errors.New("sort must be created_at, updated_at or submitted_at")
+	}
+
+	var err error
+	if f.From, err = parseDateParam(c.Query("from"), false); err != nil {
+		return f, errors.New("invalid from date")
 	}
+	if f.To, err = parseDateParam(c.Query("to"), true); err != nil {
+		return f, errors.New("invalid to date")
+	}
+	if f.MinPoints, err = parseIntParam(c.Query("minPoints")); err != nil {
+		return f, errors.New("invalid minPoints")
+	}
+	if f.MaxPoints, err = parseIntParam(c.Query("maxPoints")); err != nil {
+		return f, errors.New("invalid maxPoints")
+	}
+	return f, nil
+}
+
+func splitCSV(v string) []string {
+	var out []string
+	for _, p := range strings.Split(v, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
 
+// parseDateParam terima RFC3339 atau YYYY-MM-DD; endOfDay untuk batas "to" tanggal saja.
+func parseDateParam(v string, endOfDay bool) (*time.Time, error) {
+	if v == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return &t, nil
+	}
+	t, err := time.Parse("2006-01-02", v)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"message": "failed to fetch achievements"})
+		return nil, err
+	}
+	if endOfDay {
+		t = t.Add(24*time.Hour - time.Nanosecond)
+	}
+	return &t, nil
+}
+
+func parseIntParam(v string) (*int, error) {
+	if v == "" {
+		return nil, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return nil, err
 	}
-	return c.JSON(fiber.Map{"data": data})
+	return &n, nil
 }
 
 //
@@ -93,7 +233,8 @@ func (s *AchievementService) GetAchievements(c *fiber.Ctx) error {
 
 // CreateAchievement godoc
 // @Summary Create achievement (draft)
-// @Description Mahasiswa membuat prestasi -> draft. Mongo dibuat dulu lalu Postgres reference dibuat.
+// @Description Mahasiswa membuat prestasi -> draft. Reference Postgres + outbox ditulis dalam satu transaksi, lalu detail Mongo diterapkan dari outbox.
+// @Description details divalidasi sesuai skema achievementType (competition, publication, organization, certification, academic, other).
 // @Tags Achievements
 // @Security BearerAuth
 // @Accept json
@@ -101,8 +242,9 @@ func (s *AchievementService) GetAchievements(c *fiber.Ctx) error {
 // @Param body body model.AchievementUpsertRequest true "Create payload"
 // @Success 201 {object} model.AchievementCreateResponse
 // @Failure 400 {object} model.ErrorResponse
+// @Failure 422 {object} model.ValidationErrorResponse
 // @Failure 401 {object} model.ErrorResponse
-// @Failure 403 {object} model.ErrorResponse
+// @Failure 403 {object} model.TransitionErrorResponse
 // @Failure 404 {object} model.ErrorResponse
 // @Failure 500 {object} model.ErrorResponse
 // @Router /achievements [post]
@@ -111,16 +253,22 @@ func (s *AchievementService) CreateAchievement(c *fiber.Ctx) error {
 	if !ok {
 		return c.SendStatus(fiber.StatusUnauthorized)
 	}
-	if role != "Mahasiswa" {
-		return c.SendStatus(fiber.StatusForbidden)
+	t, err := model.AchievementWorkflow.Fire(model.ActionCreate, "", role, model.TransitionContext{})
+	if err != nil {
+		return respondTransitionError(c, err)
 	}
 
 	var body model.AchievementUpsertRequest
 	if err := c.BodyParser(&body); err != nil {
 		return c.Status(400).JSON(fiber.Map{"message": "invalid body"})
 	}
-	if body.AchievementType == "" || body.Title == "" || body.Description == "" {
-		return c.Status(400).JSON(fiber.Map{"message": "achievementType, title, description are required"})
+	achType, err := s.activeType(body.AchievementType)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"message": "failed to load achievement type"})
+	}
+	details, fieldErrs := model.ValidateAchievementUpsert(&body, achType)
+	if len(fieldErrs) > 0 {
+		return respondValidationError(c, fieldErrs)
 	}
 
 	studentID, ok2, err := s.Repo.GetStudentIDByUserID(userID)
@@ -132,34 +280,43 @@ func (s *AchievementService) CreateAchievement(c *fiber.Ctx) error {
 	}
 
 	refID := uuid.New().String()
+	now := time.Now()
 
-	// 1) Mongo dulu
+	// 1) ObjectID dibuat di sini supaya reference Postgres bisa langsung menyimpannya
 	doc := &model.AchievementMongo{
+		ID:               primitive.NewObjectID(),
 		AchievementRefID: refID,
 		StudentID:        studentID,
 		AchievementType:  body.AchievementType,
 		Title:            body.Title,
 		Description:      body.Description,
-		Details:          body.Details,
+		Details:          details,
 		Tags:             body.Tags,
-		Points:           body.Points,
+		Points:           achType.PointsForDetails(details),
+		Attachments:      []model.Attachment{},
+		CreatedAt:        now,
+		UpdatedAt:        now,
 	}
 
-	mongoID, err := s.MongoRepo.Create(doc)
+	payload, err := bson.MarshalExtJSON(doc, true, false)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"message": "failed to create detail"})
+		return c.Status(500).JSON(fiber.Map{"message": "failed to encode detail"})
 	}
 
-	// 2) Postgres insert dengan mongo id (NOT NULL aman)
-	if err := s.Repo.CreateDraftWithMongo(refID, studentID, mongoID.Hex()); err != nil {
-		_ = s.MongoRepo.Delete(mongoID) // rollback
+	// 2) Postgres reference + outbox dalam satu transaksi (tidak ada dokumen Mongo yatim)
+	summary := model.AchievementSummary{AchievementType: doc.AchievementType, Points: doc.Points, Tags: doc.Tags}
+	if err := s.Repo.CreateDraftWithMongo(refID, studentID, doc.ID.Hex(), userID, summary, payload); err != nil {
 		return c.Status(500).JSON(fiber.Map{"message": "failed to create reference"})
 	}
 
+	// 3) terapkan ke Mongo sekarang; kalau gagal, worker outbox akan retry
+	s.Outbox.Dispatch(refID)
+	s.publish(model.EventAchievementCreated, refID, userID, t.Target(), "")
+
 	return c.Status(201).JSON(fiber.Map{
 		"data": fiber.Map{
 			"id":     refID,
-			"status": "draft",
+			"status": t.Target(),
 			"detail": doc,
 		},
 	})
@@ -189,30 +346,12 @@ func (s *AchievementService) GetAchievementDetail(c *fiber.Ctx) error {
 		return c.SendStatus(fiber.StatusUnauthorized)
 	}
 
-	var mongoID *string
-	var status string
-	var okRef bool
-	var err error
-
-	switch role {
-	case "Admin":
-		mongoID, status, okRef, err = s.Repo.GetRefForDetailAdmin(refID)
-	case "Mahasiswa":
-		mongoID, status, okRef, err = s.Repo.GetRefForDetailStudent(refID, userID)
-	case "Dosen Wali":
-		mongoID, status, okRef, err = s.Repo.GetRefForDetailSupervisor(refID, userID)
-	default:
-		return c.SendStatus(fiber.StatusForbidden)
-	}
-
+	ref, err := s.resolveRef(role, userID, refID, getPermissions(c))
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"message": "failed to fetch reference"})
-	}
-	if !okRef || mongoID == nil {
-		return c.Status(404).JSON(fiber.Map{"message": "achievement not found"})
+		return respondRefError(c, err)
 	}
 
-	oid, err := primitive.ObjectIDFromHex(*mongoID)
+	oid, err := primitive.ObjectIDFromHex(*ref.MongoID)
 	if err != nil {
 		return c.Status(500).JSON(fiber.Map{"message": "invalid mongo id"})
 	}
@@ -221,22 +360,41 @@ func (s *AchievementService) GetAchievementDetail(c *fiber.Ctx) error {
 	if err != nil {
 		return c.Status(404).JSON(fiber.Map{"message": "achievement detail not found"})
 	}
+	resolveAttachmentURLs(refID, detail)
+
+	rejectionNote, revisionCount, err := s.Repo.GetReviewInfo(refID)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"message": "failed to fetch reference"})
+	}
+	approvals, err := s.Repo.ListApprovals(refID)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"message": "failed to fetch approvals"})
+	}
+	allowed := model.AchievementWorkflow.AllowedActions(ref.Status, role)
+	if ref.Status == model.StatusAwaitingApproval {
+		// action tahap tergantung permission tahap berjalan, bukan role
+		allowed = append(allowed, model.StageActions(approvals, getPermissions(c))...)
+	}
 
 	return c.JSON(fiber.Map{
 		"data": fiber.Map{
-			"id":     refID,
-			"status": status,
-			"detail": detail,
+			"id":             refID,
+			"status":         ref.Status,
+			"allowedActions": allowed,
+			"rejectionNote":  rejectionNote,
+			"revisionCount":  revisionCount,
+			"detail":         detail,
+			"approvals":      approvals,
 		},
 	})
 }
 
 //
-// ===== UPDATE (DRAFT) =====
+// ===== UPDATE (DRAFT / REVISION) =====
 //
 
 // UpdateAchievement godoc
-// @Summary Update achievement (draft only)
+// @Summary Update achievement (draft / revision only)
 // @Tags Achievements
 // @Security BearerAuth
 // @Accept json
@@ -245,10 +403,11 @@ func (s *AchievementService) GetAchievementDetail(c *fiber.Ctx) error {
 // @Param body body model.AchievementUpsertRequest true "Update payload"
 // @Success 200 {object} model.MessageResponse
 // @Failure 400 {object} model.ErrorResponse
+// @Failure 422 {object} model.ValidationErrorResponse
 // @Failure 401 {object} model.ErrorResponse
 // @Failure 403 {object} model.ErrorResponse
 // @Failure 404 {object} model.ErrorResponse
-// @Failure 422 {object} model.ErrorResponse
+// @Failure 409 {object} model.TransitionErrorResponse
 // @Failure 500 {object} model.ErrorResponse
 // @Router /achievements/{id} [put]
 func (s *AchievementService) UpdateAchievement(c *fiber.Ctx) error {
@@ -258,40 +417,49 @@ func (s *AchievementService) UpdateAchievement(c *fiber.Ctx) error {
 	if !ok {
 		return c.SendStatus(fiber.StatusUnauthorized)
 	}
-	if role != "Mahasiswa" {
-		return c.SendStatus(fiber.StatusForbidden)
-	}
 
-	mongoID, status, okRef, err := s.Repo.GetRefForDetailStudent(refID, userID)
-	if err != nil || !okRef || mongoID == nil {
-		return c.Status(404).JSON(fiber.Map{"message": "achievement not found"})
+	ref, err := s.resolveRef(role, userID, refID, getPermissions(c))
+	if err != nil {
+		return respondRefError(c, err)
 	}
-	if status != "draft" {
-		return c.Status(422).JSON(fiber.Map{"message": "only draft can be updated"})
+	if _, err := model.AchievementWorkflow.Fire(model.ActionEdit, ref.Status, role, model.TransitionContext{}); err != nil {
+		return respondTransitionError(c, err)
 	}
 
 	var body model.AchievementUpsertRequest
 	if err := c.BodyParser(&body); err != nil {
 		return c.Status(400).JSON(fiber.Map{"message": "invalid body"})
 	}
-
-	oid, err := primitive.ObjectIDFromHex(*mongoID)
+	achType, err := s.activeType(body.AchievementType)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"message": "invalid mongo id"})
+		return c.Status(500).JSON(fiber.Map{"message": "failed to load achievement type"})
+	}
+	details, fieldErrs := model.ValidateAchievementUpsert(&body, achType)
+	if len(fieldErrs) > 0 {
+		return respondValidationError(c, fieldErrs)
 	}
 
-	update := map[string]interface{}{
-		"achievementType": body.AchievementType,
+	summary := model.AchievementSummary{
+		AchievementType: body.AchievementType,
+		Points:          achType.PointsForDetails(details),
+		Tags:            body.Tags,
+	}
+	// Mongo ditulis lewat outbox, berurutan setelah operasi sebelumnya untuk prestasi ini
+	fields, err := bson.MarshalExtJSON(bson.M{
+		"achievementType": summary.AchievementType,
 		"title":           body.Title,
 		"description":     body.Description,
-		"details":         body.Details,
-		"tags":            body.Tags,
-		"points":          body.Points,
-	}
-
-	if err := s.MongoRepo.Update(oid, update); err != nil {
+		"details":         details,
+		"tags":            summary.Tags,
+		"points":          summary.Points,
+	}, true, false)
+	if err != nil {
 		return c.Status(500).JSON(fiber.Map{"message": "failed to update achievement"})
 	}
+	if err := s.Repo.UpdateDetail(refID, userID, summary, fields); err != nil {
+		return respondActionError(c, err, "failed to update achievement")
+	}
+	s.Outbox.Dispatch(refID)
 
 	return c.JSON(fiber.Map{"message": "achievement updated"})
 }
@@ -309,6 +477,8 @@ func (s *AchievementService) UpdateAchievement(c *fiber.Ctx) error {
 // @Success 200 {object} model.MessageResponse
 // @Failure 401 {object} model.ErrorResponse
 // @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 409 {object} model.TransitionErrorResponse
 // @Failure 500 {object} model.ErrorResponse
 // @Router /achievements/{id} [delete]
 func (s *AchievementService) DeleteAchievement(c *fiber.Ctx) error {
@@ -318,23 +488,21 @@ func (s *AchievementService) DeleteAchievement(c *fiber.Ctx) error {
 	if !ok {
 		return c.SendStatus(fiber.StatusUnauthorized)
 	}
-	if role != "Mahasiswa" {
-		return c.SendStatus(fiber.StatusForbidden)
-	}
 
-	mongoID, status, okRef, err := s.Repo.GetRefForDetailStudent(refID, userID)
-	if err != nil || !okRef || mongoID == nil || status != "draft" {
-		return c.Status(403).JSON(fiber.Map{"message": "achievement cannot be deleted"})
+	ref, err := s.resolveRef(role, userID, refID, getPermissions(c))
+	if err != nil {
+		return respondRefError(c, err)
 	}
-
-	if err := s.Repo.SoftDelete(refID); err != nil {
-		return c.Status(500).JSON(fiber.Map{"message": "failed to delete achievement"})
+	if _, err := model.AchievementWorkflow.Fire(model.ActionDelete, ref.Status, role, model.TransitionContext{}); err != nil {
+		return respondTransitionError(c, err)
 	}
 
-	// optional: tandai di mongo juga biar konsisten
-	if oid, err := primitive.ObjectIDFromHex(*mongoID); err == nil {
-		_ = s.MongoRepo.Update(oid, map[string]interface{}{"isDeleted": true})
+	// isDeleted di Mongo ikut lewat outbox di transaksi yang sama
+	if err := s.Repo.SoftDelete(refID, userID); err != nil {
+		return respondActionError(c, err, "failed to delete achievement")
 	}
+	s.Outbox.Dispatch(refID)
+	s.publish(model.EventAchievementDeleted, refID, userID, model.StatusDeleted, "")
 
 	return c.JSON(fiber.Map{"message": "achievement deleted"})
 }
@@ -344,7 +512,8 @@ func (s *AchievementService) DeleteAchievement(c *fiber.Ctx) error {
 //
 
 // SubmitAchievement godoc
-// @Summary Submit achievement (draft -> submitted)
+// @Summary Submit achievement (draft/revision -> submitted)
+// @Description Submit ulang dari revision menambah revisionCount.
 // @Tags Achievements
 // @Security BearerAuth
 // @Produce json
@@ -352,28 +521,78 @@ func (s *AchievementService) DeleteAchievement(c *fiber.Ctx) error {
 // @Success 200 {object} model.MessageResponse
 // @Failure 401 {object} model.ErrorResponse
 // @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 409 {object} model.TransitionErrorResponse
 // @Router /achievements/{id}/submit [post]
 func (s *AchievementService) SubmitAchievement(c *fiber.Ctx) error {
 	role, userID, ok := getRoleAndUserID(c)
 	if !ok {
 		return c.SendStatus(fiber.StatusUnauthorized)
 	}
-	if role != "Mahasiswa" {
-		return c.SendStatus(fiber.StatusForbidden)
+	refID := c.Params("id")
+
+	ref, err := s.resolveRef(role, userID, refID, getPermissions(c))
+	if err != nil {
+		return respondRefError(c, err)
+	}
+	if _, err := model.AchievementWorkflow.Fire(model.ActionSubmit, ref.Status, role, model.TransitionContext{}); err != nil {
+		return respondTransitionError(c, err)
 	}
 
-	if err := s.Repo.Submit(c.Params("id"), userID); err != nil {
-		return c.Status(403).JSON(fiber.Map{"message": "cannot submit"})
+	if err := s.Repo.Submit(refID, userID); err != nil {
+		return respondActionError(c, err, "cannot submit")
 	}
+	s.publish(model.EventAchievementSubmitted, refID, userID, model.StatusSubmitted, "")
 	return c.JSON(fiber.Map{"message": "achievement submitted"})
 }
 
+//
+// ===== REVISION =====
+//
+
+// ReviseAchievement godoc
+// @Summary Start revision (rejected -> revision)
+// @Description Mahasiswa membuka kembali prestasi yang ditolak untuk diperbaiki lalu disubmit ulang. Catatan penolakan tetap tersimpan.
+// @Tags Achievements
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Achievement ID"
+// @Success 200 {object} model.MessageResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 409 {object} model.TransitionErrorResponse
+// @Router /achievements/{id}/revise [post]
+func (s *AchievementService) ReviseAchievement(c *fiber.Ctx) error {
+	role, userID, ok := getRoleAndUserID(c)
+	if !ok {
+		return c.SendStatus(fiber.StatusUnauthorized)
+	}
+	refID := c.Params("id")
+
+	ref, err := s.resolveRef(role, userID, refID, getPermissions(c))
+	if err != nil {
+		return respondRefError(c, err)
+	}
+	if _, err := model.AchievementWorkflow.Fire(model.ActionRevise, ref.Status, role, model.TransitionContext{}); err != nil {
+		return respondTransitionError(c, err)
+	}
+
+	if err := s.Repo.StartRevision(refID, userID); err != nil {
+		return respondActionError(c, err, "cannot start revision")
+	}
+	return c.JSON(fiber.Map{"message": "achievement in revision"})
+}
+
 //
 // ===== VERIFY / REJECT =====
 //
 
 // VerifyAchievement godoc
-// @Summary Verify achievement (submitted -> verified)
+// @Summary Verify achievement (submitted -> verified / awaiting_approval)
+// @Description Poin dihitung ulang dari aturan katalog lalu dibekukan (tidak berubah walau aturan diganti).
+// @Description Jika prestasi cocok dengan aturan approval-stages (mis. level national/international), status menjadi
+// @Description awaiting_approval dan baru verified setelah semua tahap disetujui.
 // @Tags Achievements
 // @Security BearerAuth
 // @Produce json
@@ -381,22 +600,107 @@ func (s *AchievementService) SubmitAchievement(c *fiber.Ctx) error {
 // @Success 200 {object} model.MessageResponse
 // @Failure 401 {object} model.ErrorResponse
 // @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 409 {object} model.TransitionErrorResponse
+// @Failure 503 {object} model.ErrorResponse
 // @Router /achievements/{id}/verify [post]
 func (s *AchievementService) VerifyAchievement(c *fiber.Ctx) error {
 	role, userID, ok := getRoleAndUserID(c)
 	if !ok {
 		return c.SendStatus(fiber.StatusUnauthorized)
 	}
-	if role != "Dosen Wali" {
-		return c.SendStatus(fiber.StatusForbidden)
+	refID := c.Params("id")
+
+	ref, err := s.resolveRef(role, userID, refID, getPermissions(c))
+	if err != nil {
+		return respondRefError(c, err)
+	}
+	if _, err := model.AchievementWorkflow.Fire(model.ActionVerify, ref.Status, role, model.TransitionContext{}); err != nil {
+		return respondTransitionError(c, err)
+	}
+
+	plan, err := s.planVerification(refID, *ref.MongoID)
+	if err != nil {
+		return respondPlanError(c, err)
 	}
 
-	if err := s.Repo.Verify(c.Params("id"), userID); err != nil {
-		return c.Status(403).JSON(fiber.Map{"message": "cannot verify"})
+	if err := s.Repo.Verify(refID, userID, plan.Points, plan.Stages); err != nil {
+		return respondActionError(c, err, "cannot verify")
 	}
+	if len(plan.Stages) > 0 {
+		s.publish(model.EventAchievementAwaitingApproval, refID, userID, model.StatusAwaitingApproval, "")
+		return c.JSON(fiber.Map{"message": "achievement verified by advisor, awaiting approval"})
+	}
+	s.Outbox.Dispatch(refID)
+	s.publish(model.EventAchievementVerified, refID, userID, model.StatusVerified, "")
+
 	return c.JSON(fiber.Map{"message": "achievement verified"})
 }
 
+// verificationPlan poin yang dibekukan + tahap persetujuan yang berlaku untuk satu prestasi
+type verificationPlan struct {
+	Points int
+	Stages []model.ApprovalStage
+}
+
+// Kesalahan planVerification: detail Mongo belum/tidak bisa dibaca versi terbarunya
+var (
+	errDetailPending     = errors.New("achievement detail is still being synchronized, try again later")
+	errDetailUnavailable = errors.New("achievement detail unavailable, try again later")
+)
+
+// planVerification hitung ulang poin dengan aturan katalog saat ini (dibekukan oleh Verify) dan pilih
+// tahap persetujuan dari tipe + details.level. Tipe yang sudah hilang dari katalog memakai poin yang tersimpan.
+// Poin dan tahap dihitung dari dokumen Mongo, jadi ditolak selama reference masih punya entry outbox
+// yang belum diterapkan (dokumen bisa tertinggal dari edit terakhir).
+func (s *AchievementService) planVerification(refID, mongoID string) (verificationPlan, error) {
+	oid, err := primitive.ObjectIDFromHex(mongoID)
+	if err != nil {
+		return verificationPlan{}, err
+	}
+	s.Outbox.Dispatch(refID)
+	pending, err := s.Outbox.Repo.HasUnapplied(refID)
+	if err != nil {
+		return verificationPlan{}, err
+	}
+	if pending {
+		return verificationPlan{}, errDetailPending
+	}
+	doc, err := s.MongoRepo.FindByID(oid)
+	if err != nil {
+		return verificationPlan{}, fmt.Errorf("%w: %v", errDetailUnavailable, err)
+	}
+	stages, err := s.StageRepo.List(false)
+	if err != nil {
+		return verificationPlan{}, err
+	}
+	plan := verificationPlan{
+		Points: doc.Points,
+		Stages: model.ApplicableStages(stages, doc.AchievementType, doc.Details),
+	}
+
+	t, found, err := s.TypeRepo.GetByCode(doc.AchievementType)
+	if err != nil {
+		return verificationPlan{}, err
+	}
+	if found {
+		plan.Points = t.PointsForDetails(doc.Details)
+	}
+	return plan, nil
+}
+
+// respondPlanError: outbox belum diterapkan -> 409, Mongo tidak bisa dibaca -> 503
+func respondPlanError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, errDetailPending):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"message": err.Error()})
+	case errors.Is(err, errDetailUnavailable):
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"message": errDetailUnavailable.Error()})
+	default:
+		return c.Status(500).JSON(fiber.Map{"message": "failed to calculate points"})
+	}
+}
+
 // RejectAchievement godoc
 // @Summary Reject achievement (submitted -> rejected)
 // @Tags Achievements
@@ -409,24 +713,34 @@ func (s *AchievementService) VerifyAchievement(c *fiber.Ctx) error {
 // @Failure 400 {object} model.ErrorResponse
 // @Failure 401 {object} model.ErrorResponse
 // @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 409 {object} model.TransitionErrorResponse
 // @Router /achievements/{id}/reject [post]
 func (s *AchievementService) RejectAchievement(c *fiber.Ctx) error {
 	role, userID, ok := getRoleAndUserID(c)
 	if !ok {
 		return c.SendStatus(fiber.StatusUnauthorized)
 	}
-	if role != "Dosen Wali" {
-		return c.SendStatus(fiber.StatusForbidden)
-	}
+	refID := c.Params("id")
 
 	var body model.AchievementRejectRequest
-	if err := c.BodyParser(&body); err != nil || strings.TrimSpace(body.Note) == "" {
+	if err := c.BodyParser(&body); err != nil {
 		return c.Status(400).JSON(fiber.Map{"message": "note is required"})
 	}
+	body.Note = strings.TrimSpace(body.Note)
 
-	if err := s.Repo.Reject(c.Params("id"), body.Note, userID); err != nil {
-		return c.Status(403).JSON(fiber.Map{"message": "cannot reject"})
+	ref, err := s.resolveRef(role, userID, refID, getPermissions(c))
+	if err != nil {
+		return respondRefError(c, err)
+	}
+	if _, err := model.AchievementWorkflow.Fire(model.ActionReject, ref.Status, role, model.TransitionContext{Note: body.Note}); err != nil {
+		return respondTransitionError(c, err)
+	}
+
+	if err := s.Repo.Reject(refID, body.Note, userID); err != nil {
+		return respondActionError(c, err, "cannot reject")
 	}
+	s.publish(model.EventAchievementRejected, refID, userID, model.StatusRejected, body.Note)
 	return c.JSON(fiber.Map{"message": "achievement rejected"})
 }
 
@@ -435,112 +749,58 @@ func (s *AchievementService) RejectAchievement(c *fiber.Ctx) error {
 //
 
 // GetAchievementHistory godoc
-// @Summary Get achievement history (implicit)
+// @Summary Get achievement history
+// @Description Event log perubahan status (siapa, kapan, dari-ke status, catatan, tahap persetujuan).
+// @Description Untuk mahasiswa pemilik, dosen wali, dan admin, komentar ikut disisipkan sesuai waktu (type=comment).
+// @Description approvals berisi status tiap tahap rantai persetujuan pada putaran revisi saat ini.
 // @Tags Achievements
 // @Security BearerAuth
 // @Produce json
 // @Param id path string true "Achievement ID"
 // @Success 200 {object} model.AchievementHistoryResponse
 // @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
 // @Failure 404 {object} model.ErrorResponse
 // @Failure 500 {object} model.ErrorResponse
 // @Router /achievements/{id}/history [get]
 func (s *AchievementService) GetAchievementHistory(c *fiber.Ctx) error {
-	refID := c.Params("id")
-
-	status, ok, err := s.Repo.GetStatusByID(refID)
-	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"message": "failed to fetch achievement"})
-	}
-	if !ok {
-		return c.Status(404).JSON(fiber.Map{"message": "achievement not found"})
-	}
-
-	history, err := s.Repo.GetImplicitHistory(refID)
-	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"message": "failed to fetch history"})
-	}
-
-	return c.JSON(fiber.Map{
-		"data": fiber.Map{
-			"currentStatus": status,
-			"history":       history,
-		},
-	})
-}
-
-//
-// ===== UPLOAD ATTACHMENT (DRAFT ONLY) =====
-//
-
-// UploadAchievementAttachment godoc
-// @Summary Upload attachment (draft only)
-// @Tags Achievements
-// @Security BearerAuth
-// @Accept multipart/form-data
-// @Produce json
-// @Param id path string true "Achievement ID"
-// @Param file formData file true "Attachment file"
-// @Success 201 {object} model.AttachmentResponse
-// @Failure 400 {object} model.ErrorResponse
-// @Failure 401 {object} model.ErrorResponse
-// @Failure 403 {object} model.ErrorResponse
-// @Failure 404 {object} model.ErrorResponse
-// @Failure 422 {object} model.ErrorResponse
-// @Failure 500 {object} model.ErrorResponse
-// @Router /achievements/{id}/attachments [post]
-func (s *AchievementService) UploadAchievementAttachment(c *fiber.Ctx) error {
-	refID := c.Params("id")
-
 	role, userID, ok := getRoleAndUserID(c)
 	if !ok {
 		return c.SendStatus(fiber.StatusUnauthorized)
 	}
-	if role != "Mahasiswa" {
-		return c.SendStatus(fiber.StatusForbidden)
-	}
-
-	mongoID, status, okRef, err := s.Repo.GetRefForDetailStudent(refID, userID)
-	if err != nil || !okRef || mongoID == nil {
-		return c.Status(404).JSON(fiber.Map{"message": "achievement not found"})
-	}
-	if status != "draft" {
-		return c.Status(422).JSON(fiber.Map{"message": "only draft can upload attachments"})
-	}
+	refID := c.Params("id")
 
-	file, err := c.FormFile("file")
+	ref, err := s.resolveRef(role, userID, refID, getPermissions(c))
 	if err != nil {
-		return c.Status(400).JSON(fiber.Map{"message": "file is required"})
+		return respondRefError(c, err)
 	}
 
-	dir := filepath.Join("uploads", "achievements", refID)
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return c.Status(500).JSON(fiber.Map{"message": "failed to prepare directory"})
+	history, err := s.Repo.GetHistory(refID)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"message": "failed to fetch history"})
 	}
-
-	filename := uuid.New().String() + "_" + strings.ReplaceAll(file.Filename, " ", "_")
-	path := filepath.Join(dir, filename)
-
-	if err := c.SaveFile(file, path); err != nil {
-		return c.Status(500).JSON(fiber.Map{"message": "failed to save file"})
+	if commentAllowed(role) {
+		comments, err := s.CommentRepo.ListByAchievement(refID)
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{"message": "failed to fetch comments"})
+		}
+		history = mergeTimeline(history, comments)
 	}
-
-	fileURL := "/" + filepath.ToSlash(path)
-
-	oid, err := primitive.ObjectIDFromHex(*mongoID)
+	approvals, err := s.Repo.ListApprovals(refID)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"message": "invalid mongo id"})
+		return c.Status(500).JSON(fiber.Map{"message": "failed to fetch approvals"})
 	}
-
-	attachment := model.Attachment{
-		FileName: filename,
-		FileURL:  fileURL,
-		FileType: file.Header.Get("Content-Type"),
+	allowed := model.AchievementWorkflow.AllowedActions(ref.Status, role)
+	if ref.Status == model.StatusAwaitingApproval {
+		// action tahap tergantung permission tahap berjalan, bukan role
+		allowed = append(allowed, model.StageActions(approvals, getPermissions(c))...)
 	}
 
-	if err := s.MongoRepo.AddAttachment(oid, attachment); err != nil {
-		return c.Status(500).JSON(fiber.Map{"message": "failed to attach file"})
-	}
-
-	return c.Status(201).JSON(fiber.Map{"data": attachment})
+	return c.JSON(fiber.Map{
+		"data": fiber.Map{
+			"currentStatus": ref.Status,
+			"history":       history,
+			"approvals":     approvals,
+		},
+	})
 }
@@ -0,0 +1,198 @@
+package service
+
+import (
+	"errors"
+	"strings"
+
+	"project_uas/app/model"
+	"project_uas/app/repository"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type AchievementTypeService struct {
+	Repo repository.AchievementTypeRepository
+}
+
+func NewAchievementTypeService(repo repository.AchievementTypeRepository) *AchievementTypeService {
+	return &AchievementTypeService{Repo: repo}
+}
+
+type achievementTypeReq struct {
+	Code        string            `json:"code"`
+	Name        string            `json:"name"`
+	Schema      string            `json:"schema"`
+	Description *string           `json:"description"`
+	Levels      []string          `json:"levels"`
+	IsActive    *bool             `json:"is_active"`
+	PointRules  []model.PointRule `json:"point_rules"`
+}
+
+func (r achievementTypeReq) toModel(code string) *model.AchievementType {
+	t := &model.AchievementType{
+		Code:        strings.TrimSpace(code),
+		Name:        strings.TrimSpace(r.Name),
+		Schema:      strings.TrimSpace(r.Schema),
+		Description: r.Description,
+		Levels:      r.Levels,
+		IsActive:    true,
+		PointRules:  r.PointRules,
+	}
+	if r.IsActive != nil {
+		t.IsActive = *r.IsActive
+	}
+	if t.Levels == nil {
+		t.Levels = []string{}
+	}
+	return t
+}
+
+// ListAchievementTypes godoc
+// @Summary List achievement types
+// @Description Katalog tipe prestasi beserta level dan aturan poin. Nonaktif ikut jika include_inactive=true.
+// @Tags Achievement Types
+// @Security BearerAuth
+// @Produce json
+// @Param include_inactive query bool false "Sertakan tipe nonaktif"
+// @Success 200 {object} model.AchievementTypeListResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /achievement-types [get]
+func (s *AchievementTypeService) ListAchievementTypes(c *fiber.Ctx) error {
+	types, err := s.Repo.List(c.QueryBool("include_inactive", false))
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"message": "failed to fetch achievement types"})
+	}
+	return c.JSON(fiber.Map{"data": types})
+}
+
+// GetAchievementType godoc
+// @Summary Get achievement type
+// @Tags Achievement Types
+// @Security BearerAuth
+// @Produce json
+// @Param code path string true "Achievement type code"
+// @Success 200 {object} model.AchievementTypeDetailResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /achievement-types/{code} [get]
+func (s *AchievementTypeService) GetAchievementType(c *fiber.Ctx) error {
+	t, found, err := s.Repo.GetByCode(c.Params("code"))
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"message": "failed to fetch achievement type"})
+	}
+	if !found {
+		return c.Status(404).JSON(fiber.Map{"message": "achievement type not found"})
+	}
+	return c.JSON(fiber.Map{"data": t})
+}
+
+// CreateAchievementType godoc
+// @Summary Create achievement type
+// @Description schema: competition | publication | organization | certification | academic | other.
+// @Description point_rules: level "" = semua level, rank 0 = semua rank; aturan paling spesifik yang dipakai.
+// @Tags Achievement Types
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param body body model.AchievementTypeUpsertRequest true "Request body"
+// @Success 201 {object} model.AchievementTypeDetailResponse
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 409 {object} model.ErrorResponse
+// @Failure 422 {object} model.ValidationErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /achievement-types [post]
+func (s *AchievementTypeService) CreateAchievementType(c *fiber.Ctx) error {
+	var body achievementTypeReq
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(400).JSON(fiber.Map{"message": "invalid body"})
+	}
+
+	t := body.toModel(body.Code)
+	if errs := t.Validate(); len(errs) > 0 {
+		return respondValidationError(c, errs)
+	}
+
+	if err := s.Repo.Create(t); err != nil {
+		if errors.Is(err, repository.ErrAchievementTypeExists) {
+			return c.Status(409).JSON(fiber.Map{"message": err.Error()})
+		}
+		return c.Status(500).JSON(fiber.Map{"message": "failed to create achievement type"})
+	}
+	return s.respondType(c, 201, t.Code)
+}
+
+// UpdateAchievementType godoc
+// @Summary Update achievement type
+// @Description Mengganti seluruh field dan aturan poin. Poin prestasi yang sudah verified tidak berubah.
+// @Tags Achievement Types
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param code path string true "Achievement type code"
+// @Param body body model.AchievementTypeUpsertRequest true "Request body (code diabaikan)"
+// @Success 200 {object} model.AchievementTypeDetailResponse
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 422 {object} model.ValidationErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /achievement-types/{code} [put]
+func (s *AchievementTypeService) UpdateAchievementType(c *fiber.Ctx) error {
+	var body achievementTypeReq
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(400).JSON(fiber.Map{"message": "invalid body"})
+	}
+
+	t := body.toModel(c.Params("code"))
+	if errs := t.Validate(); len(errs) > 0 {
+		return respondValidationError(c, errs)
+	}
+
+	found, err := s.Repo.Update(t)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"message": "failed to update achievement type"})
+	}
+	if !found {
+		return c.Status(404).JSON(fiber.Map{"message": "achievement type not found"})
+	}
+	return s.respondType(c, 200, t.Code)
+}
+
+// DeleteAchievementType godoc
+// @Summary Deactivate achievement type
+// @Description Soft delete (is_active=false): tidak bisa dipakai untuk prestasi baru, data lama tetap.
+// @Tags Achievement Types
+// @Security BearerAuth
+// @Produce json
+// @Param code path string true "Achievement type code"
+// @Success 200 {object} model.MessageResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /achievement-types/{code} [delete]
+func (s *AchievementTypeService) DeleteAchievementType(c *fiber.Ctx) error {
+	found, err := s.Repo.Deactivate(c.Params("code"))
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"message": "failed to deactivate achievement type"})
+	}
+	if !found {
+		return c.Status(404).JSON(fiber.Map{"message": "achievement type not found"})
+	}
+	return c.JSON(fiber.Map{"message": "achievement type deactivated"})
+}
+
+func (s *AchievementTypeService) respondType(c *fiber.Ctx, status int, code string) error {
+	t, _, err := s.Repo.GetByCode(code)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"message": "failed to fetch achievement type"})
+	}
+	return c.Status(status).JSON(fiber.Map{"data": t})
+}
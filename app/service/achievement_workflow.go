@@ -0,0 +1,135 @@
+package service
+
+import (
+	"errors"
+
+	"project_uas/app/model"
+	"project_uas/app/repository"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// achievementRef reference prestasi yang sudah di-resolve sesuai scope role.
+type achievementRef struct {
+	MongoID *string
+	Status  string
+}
+
+// resolveRef ambil reference prestasi sesuai scope role
+// (Admin: semua, Mahasiswa: miliknya, Dosen Wali: mahasiswa bimbingan,
+// role lain: prestasi yang tahap berjalan / sudah diputuskan memakai permission-nya).
+func (s *AchievementService) resolveRef(role, userID, refID string, permissions []string) (*achievementRef, error) {
+	var (
+		mongoID *string
+		status  string
+		found   bool
+		err     error
+	)
+
+	switch role {
+	case model.RoleAdmin:
+		mongoID, status, found, err = s.Repo.GetRefForDetailAdmin(refID)
+	case model.RoleStudent:
+		mongoID, status, found, err = s.Repo.GetRefForDetailStudent(refID, userID)
+	case model.RoleLecturer:
+		mongoID, status, found, err = s.Repo.GetRefForDetailSupervisor(refID, userID)
+	default:
+		// Reviewer Fakultas / role lain yang memegang permission tahap persetujuan
+		mongoID, status, found, err = s.Repo.GetRefForDetailReviewer(refID, permissions)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	if !found || mongoID == nil {
+		return nil, repository.ErrNotFoundOrForbidden
+	}
+	return &achievementRef{MongoID: mongoID, Status: status}, nil
+}
+
+// resolveStageRef reference untuk keputusan tahap persetujuan. Tidak bergantung role: cukup memegang
+// permission tahap berjalan / yang sudah diputuskan; permission tahap berjalan dicek lagi di repository.
+func (s *AchievementService) resolveStageRef(refID string, permissions []string) (*achievementRef, error) {
+	mongoID, status, found, err := s.Repo.GetRefForDetailReviewer(refID, permissions)
+	if err != nil {
+		return nil, err
+	}
+	if !found || mongoID == nil {
+		return nil, repository.ErrNotFoundOrForbidden
+	}
+	return &achievementRef{MongoID: mongoID, Status: status}, nil
+}
+
+func respondRefError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, model.ErrRoleNotAllowed):
+		return c.SendStatus(fiber.StatusForbidden)
+	case errors.Is(err, repository.ErrNotFoundOrForbidden):
+		return c.Status(404).JSON(fiber.Map{"message": "achievement not found"})
+	default:
+		return c.Status(500).JSON(fiber.Map{"message": "failed to fetch reference"})
+	}
+}
+
+// respondTransitionError: role tidak berhak -> 403, transisi ilegal -> 409 (+ allowedActions),
+// guard gagal -> 400.
+func respondTransitionError(c *fiber.Ctx, err error) error {
+	var te *model.TransitionError
+	if !errors.As(err, &te) {
+		return c.Status(400).JSON(fiber.Map{"message": err.Error()})
+	}
+
+	status := fiber.StatusConflict
+	if errors.Is(err, model.ErrRoleNotAllowed) {
+		status = fiber.StatusForbidden
+	}
+	return c.Status(status).JSON(fiber.Map{
+		"message":        te.Error(),
+		"currentStatus":  te.From,
+		"allowedActions": te.AllowedActions,
+	})
+}
+
+// respondActionError untuk error dari repository saat menjalankan transisi.
+func respondActionError(c *fiber.Ctx, err error, message string) error {
+	var te *model.TransitionError
+	switch {
+	case errors.As(err, &te):
+		return respondTransitionError(c, err)
+	case errors.Is(err, repository.ErrNotFoundOrForbidden):
+		return c.Status(403).JSON(fiber.Map{"message": message})
+	default:
+		return c.Status(500).JSON(fiber.Map{"message": message})
+	}
+}
+
+// respondValidationError 422 dengan daftar error per field
+func respondValidationError(c *fiber.Ctx, errs []model.FieldError) error {
+	return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+		"message": "validation failed",
+		"errors":  errs,
+	})
+}
+
+// activeType tipe aktif dari katalog; nil jika tidak ada / nonaktif
+func (s *AchievementService) activeType(code string) (*model.AchievementType, error) {
+	if code == "" {
+		return nil, nil
+	}
+	t, found, err := s.TypeRepo.GetByCode(code)
+	if err != nil || !found || !t.IsActive {
+		return nil, err
+	}
+	return t, nil
+}
+
+// publish kirim event ke EventBus; dipanggil setelah perubahan tersimpan
+func (s *AchievementService) publish(eventType, refID, actorUserID, status, note string) {
+	s.Events.Publish(model.AchievementEvent{
+		Type:          eventType,
+		AchievementID: refID,
+		ActorUserID:   actorUserID,
+		Status:        status,
+		Note:          note,
+	})
+}
@@ -0,0 +1,201 @@
+package service
+
+import (
+	"errors"
+	"strings"
+
+	"project_uas/app/model"
+	"project_uas/app/repository"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ApprovalStageService struct {
+	Repo repository.ApprovalStageRepository
+}
+
+func NewApprovalStageService(repo repository.ApprovalStageRepository) *ApprovalStageService {
+	return &ApprovalStageService{Repo: repo}
+}
+
+type approvalStageReq struct {
+	Code       string   `json:"code"`
+	Name       string   `json:"name"`
+	Permission string   `json:"permission"`
+	Position   int      `json:"position"`
+	TypeCodes  []string `json:"type_codes"`
+	Levels     []string `json:"levels"`
+	IsActive   *bool    `json:"is_active"`
+}
+
+func (r approvalStageReq) toModel(code string) *model.ApprovalStage {
+	st := &model.ApprovalStage{
+		Code:       strings.TrimSpace(code),
+		Name:       strings.TrimSpace(r.Name),
+		Permission: strings.TrimSpace(r.Permission),
+		Position:   r.Position,
+		TypeCodes:  r.TypeCodes,
+		Levels:     r.Levels,
+		IsActive:   true,
+	}
+	if r.IsActive != nil {
+		st.IsActive = *r.IsActive
+	}
+	if st.TypeCodes == nil {
+		st.TypeCodes = []string{}
+	}
+	if st.Levels == nil {
+		st.Levels = []string{}
+	}
+	return st
+}
+
+// ListApprovalStages godoc
+// @Summary List approval stages
+// @Description Aturan tahap persetujuan setelah dosen wali. type_codes / levels kosong = berlaku untuk semua.
+// @Tags Approval Stages
+// @Security BearerAuth
+// @Produce json
+// @Param include_inactive query bool false "Sertakan tahap nonaktif"
+// @Success 200 {object} model.ApprovalStageListResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /approval-stages [get]
+func (s *ApprovalStageService) ListApprovalStages(c *fiber.Ctx) error {
+	stages, err := s.Repo.List(c.QueryBool("include_inactive", false))
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"message": "failed to fetch approval stages"})
+	}
+	return c.JSON(fiber.Map{"data": stages})
+}
+
+// GetApprovalStage godoc
+// @Summary Get approval stage
+// @Tags Approval Stages
+// @Security BearerAuth
+// @Produce json
+// @Param code path string true "Approval stage code"
+// @Success 200 {object} model.ApprovalStageDetailResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /approval-stages/{code} [get]
+func (s *ApprovalStageService) GetApprovalStage(c *fiber.Ctx) error {
+	st, found, err := s.Repo.GetByCode(c.Params("code"))
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"message": "failed to fetch approval stage"})
+	}
+	if !found {
+		return c.Status(404).JSON(fiber.Map{"message": "approval stage not found"})
+	}
+	return c.JSON(fiber.Map{"data": st})
+}
+
+// CreateApprovalStage godoc
+// @Summary Create approval stage
+// @Description permission: permission yang wajib dimiliki reviewer tahap ini (mis. achievement:approve_faculty).
+// @Description Tahap diputuskan urut position; hanya berlaku untuk verifikasi berikutnya.
+// @Tags Approval Stages
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param body body model.ApprovalStageUpsertRequest true "Request body"
+// @Success 201 {object} model.ApprovalStageDetailResponse
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 409 {object} model.ErrorResponse
+// @Failure 422 {object} model.ValidationErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /approval-stages [post]
+func (s *ApprovalStageService) CreateApprovalStage(c *fiber.Ctx) error {
+	var body approvalStageReq
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(400).JSON(fiber.Map{"message": "invalid body"})
+	}
+
+	st := body.toModel(body.Code)
+	if errs := st.Validate(); len(errs) > 0 {
+		return respondValidationError(c, errs)
+	}
+
+	if err := s.Repo.Create(st); err != nil {
+		if errors.Is(err, repository.ErrApprovalStageExists) {
+			return c.Status(409).JSON(fiber.Map{"message": err.Error()})
+		}
+		return c.Status(500).JSON(fiber.Map{"message": "failed to create approval stage"})
+	}
+	return s.respondStage(c, 201, st.Code)
+}
+
+// UpdateApprovalStage godoc
+// @Summary Update approval stage
+// @Description Mengganti seluruh field. Prestasi yang sedang awaiting_approval tetap memakai tahap lamanya.
+// @Tags Approval Stages
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param code path string true "Approval stage code"
+// @Param body body model.ApprovalStageUpsertRequest true "Request body (code diabaikan)"
+// @Success 200 {object} model.ApprovalStageDetailResponse
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 422 {object} model.ValidationErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /approval-stages/{code} [put]
+func (s *ApprovalStageService) UpdateApprovalStage(c *fiber.Ctx) error {
+	var body approvalStageReq
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(400).JSON(fiber.Map{"message": "invalid body"})
+	}
+
+	st := body.toModel(c.Params("code"))
+	if errs := st.Validate(); len(errs) > 0 {
+		return respondValidationError(c, errs)
+	}
+
+	found, err := s.Repo.Update(st)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"message": "failed to update approval stage"})
+	}
+	if !found {
+		return c.Status(404).JSON(fiber.Map{"message": "approval stage not found"})
+	}
+	return s.respondStage(c, 200, st.Code)
+}
+
+// DeleteApprovalStage godoc
+// @Summary Deactivate approval stage
+// @Description Soft delete (is_active=false): tidak dipakai lagi untuk verifikasi berikutnya.
+// @Tags Approval Stages
+// @Security BearerAuth
+// @Produce json
+// @Param code path string true "Approval stage code"
+// @Success 200 {object} model.MessageResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /approval-stages/{code} [delete]
+func (s *ApprovalStageService) DeleteApprovalStage(c *fiber.Ctx) error {
+	found, err := s.Repo.Deactivate(c.Params("code"))
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"message": "failed to deactivate approval stage"})
+	}
+	if !found {
+		return c.Status(404).JSON(fiber.Map{"message": "approval stage not found"})
+	}
+	return c.JSON(fiber.Map{"message": "approval stage deactivated"})
+}
+
+func (s *ApprovalStageService) respondStage(c *fiber.Ctx, status int, code string) error {
+	st, _, err := s.Repo.GetByCode(code)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"message": "failed to fetch approval stage"})
+	}
+	return c.Status(status).JSON(fiber.Map{"data": st})
+}
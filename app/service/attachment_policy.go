@@ -0,0 +1,122 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	errAttachmentTooLarge    = errors.New("attachment too large")
+	errAttachmentUnsupported = errors.New("unsupported file type")
+)
+
+const mb = 1 << 20
+
+// AttachmentPolicy aturan upload lampiran. Tipe ditentukan dari isi file (sniffing), bukan header client.
+type AttachmentPolicy struct {
+	MaxCount int
+	// MaxSize batas ukuran (byte) per content type; content type di luar map ditolak
+	MaxSize map[string]int64
+}
+
+// ekstensi kanonik per content type (nama file disimpan dengan ekstensi ini)
+var attachmentExtensions = map[string]string{
+	"application/pdf": ".pdf",
+	"image/png":       ".png",
+	"image/jpeg":      ".jpg",
+	"image/webp":      ".webp",
+}
+
+// AttachmentPolicyFromEnv baca ATTACHMENT_MAX_COUNT (default 10) dan batas ukuran dalam MB:
+// ATTACHMENT_MAX_MB_PDF (10), ATTACHMENT_MAX_MB_PNG, ATTACHMENT_MAX_MB_JPEG, ATTACHMENT_MAX_MB_WEBP (5).
+func AttachmentPolicyFromEnv() AttachmentPolicy {
+	return AttachmentPolicy{
+		MaxCount: envInt("ATTACHMENT_MAX_COUNT", 10),
+		MaxSize: map[string]int64{
+			"application/pdf": int64(envInt("ATTACHMENT_MAX_MB_PDF", 10)) * mb,
+			"image/png":       int64(envInt("ATTACHMENT_MAX_MB_PNG", 5)) * mb,
+			"image/jpeg":      int64(envInt("ATTACHMENT_MAX_MB_JPEG", 5)) * mb,
+			"image/webp":      int64(envInt("ATTACHMENT_MAX_MB_WEBP", 5)) * mb,
+		},
+	}
+}
+
+// BodyLimit batas body request untuk Fiber: ukuran terbesar + ruang untuk header multipart.
+func (p AttachmentPolicy) BodyLimit() int {
+	var max int64
+	for _, v := range p.MaxSize {
+		if v > max {
+			max = v
+		}
+	}
+	return int(max) + mb
+}
+
+// AllowedTypes daftar content type yang diterima.
+func (p AttachmentPolicy) AllowedTypes() []string {
+	out := make([]string, 0, len(p.MaxSize))
+	for _, t := range []string{"application/pdf", "image/png", "image/jpeg", "image/webp"} {
+		if _, ok := p.MaxSize[t]; ok {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// Check sniff 512 byte pertama lalu cocokkan dengan allow-list dan batas ukuran.
+// r dikembalikan ke posisi awal supaya bisa langsung di-upload.
+func (p AttachmentPolicy) Check(r io.ReadSeeker, size int64) (string, error) {
+	head := make([]byte, 512)
+	n, err := io.ReadFull(r, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return "", err
+	}
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+
+	contentType := strings.TrimSpace(strings.SplitN(http.DetectContentType(head[:n]), ";", 2)[0])
+	limit, ok := p.MaxSize[contentType]
+	if !ok {
+		return contentType, fmt.Errorf("%w: %s", errAttachmentUnsupported, contentType)
+	}
+	if size > limit {
+		return contentType, fmt.Errorf("%w: max %d MB for %s", errAttachmentTooLarge, limit/mb, contentType)
+	}
+	return contentType, nil
+}
+
+var unsafeFilenameChars = regexp.MustCompile(`[^A-Za-z0-9_-]+`)
+
+// sanitizeFilename buang path dan karakter selain [A-Za-z0-9_-] (termasuk ekstensi ganda), lalu pakai
+// ekstensi tipe hasil sniffing (mis. "../cv final.pdf.exe" berisi PDF -> "cv_final_pdf.pdf").
+func sanitizeFilename(name, contentType string) string {
+	name = strings.ReplaceAll(name, "\\", "/")
+	name = path.Base(name)
+
+	ext := attachmentExtensions[contentType]
+	base := strings.TrimSuffix(name, path.Ext(name))
+	base = unsafeFilenameChars.ReplaceAllString(base, "_")
+	base = strings.Trim(base, "_-")
+	if len(base) > 100 {
+		base = base[:100]
+	}
+	if base == "" {
+		base = "file"
+	}
+	return base + ext
+}
+
+func envInt(key string, fallback int) int {
+	if v, err := strconv.Atoi(os.Getenv(key)); err == nil && v > 0 {
+		return v
+	}
+	return fallback
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/jpeg"
+	"io"
+
+	_ "image/png"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	thumbnailMaxSide = 320
+	thumbnailSuffix  = ".thumb.jpg"
+	// batas piksel sumber supaya gambar "bom dekompresi" tidak menghabiskan memori:
+	// 16 MP = ~64 MB RGBA saat decode; gambar lebih besar disimpan tanpa thumbnail
+	thumbnailMaxPixels = 16_000_000
+)
+
+var errThumbnailTooLarge = errors.New("image too large for thumbnail")
+
+// thumbnailable: hanya gambar. PDF belum didukung karena tidak ada renderer PDF pure-Go yang layak.
+func thumbnailable(contentType string) bool {
+	switch contentType {
+	case "image/png", "image/jpeg", "image/webp":
+		return true
+	}
+	return false
+}
+
+// generateThumbnail skala gambar agar sisi terpanjang maks thumbnailMaxSide, hasil JPEG.
+func generateThumbnail(r io.ReadSeeker) ([]byte, error) {
+	cfg, _, err := image.DecodeConfig(r)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Width*cfg.Height > thumbnailMaxPixels {
+		return nil, errThumbnailTooLarge
+	}
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	src, _, err := image.Decode(r)
+	if err != nil {
+		return nil, err
+	}
+
+	b := src.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if w > thumbnailMaxSide || h > thumbnailMaxSide {
+		if w >= h {
+			w, h = thumbnailMaxSide, max(1, h*thumbnailMaxSide/b.Dx())
+		} else {
+			w, h = max(1, w*thumbnailMaxSide/b.Dy()), thumbnailMaxSide
+		}
+	}
+
+	// latar putih untuk gambar transparan (JPEG tidak punya alpha)
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.Draw(dst, dst.Bounds(), image.White, image.Point{}, draw.Src)
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, b, draw.Over, nil)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 80}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -1,7 +1,14 @@
 package service
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"net/url"
+	"os"
 	"strings"
+	"time"
 
 	"project_uas/app/model"
 	"project_uas/app/repository"
@@ -12,11 +19,27 @@ import (
 )
 
 type AuthService struct {
-	Repo repository.AuthRepository
+	Repo   repository.AuthRepository
+	Tokens *TokenStore
+	// Email pengirim tautan reset password (nil = MAIL_DRIVER=none, lupa password tidak tersedia)
+	Email *EmailChannel
+	// ResetURL halaman reset password di frontend, token ditambahkan sebagai ?token=
+	ResetURL string
+	ResetTTL time.Duration
 }
 
-func NewAuthService(repo repository.AuthRepository) *AuthService {
-	return &AuthService{Repo: repo}
+func NewAuthService(repo repository.AuthRepository, tokens *TokenStore, email *EmailChannel) *AuthService {
+	resetURL := strings.TrimSpace(os.Getenv("PASSWORD_RESET_URL"))
+	if resetURL == "" && email != nil && email.BaseURL != "" {
+		resetURL = email.BaseURL + "/reset-password"
+	}
+	return &AuthService{
+		Repo:     repo,
+		Tokens:   tokens,
+		Email:    email,
+		ResetURL: resetURL,
+		ResetTTL: time.Duration(envInt("PASSWORD_RESET_TTL_MINUTES", 30)) * time.Minute,
+	}
 }
 
 // Login godoc
@@ -60,14 +83,15 @@ func (s *AuthService) Login(c *fiber.Ctx) error {
 		return c.Status(500).JSON(fiber.Map{"status": "error", "message": "failed to load permissions"})
 	}
 
-	accessToken, err := utils.GenerateToken(user.ID, user.RoleName, perms)
+	// satu login = satu family refresh token
+	refreshToken, familyID, err := s.Tokens.StartSession(user.ID)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"status": "error", "message": err.Error()})
+		return c.Status(500).JSON(fiber.Map{"status": "error", "message": "failed to generate refresh token"})
 	}
 
-	refreshToken, err := utils.GenerateRefreshToken(user.ID)
+	accessToken, err := utils.GenerateToken(user.ID, user.RoleName, perms, familyID)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"status": "error", "message": "failed to generate refresh token"})
+		return c.Status(500).JSON(fiber.Map{"status": "error", "message": err.Error()})
 	}
 
 	resp := model.LoginResponse{
@@ -89,7 +113,8 @@ func (s *AuthService) Login(c *fiber.Ctx) error {
 
 // Refresh godoc
 // @Summary Refresh token
-// @Description Tukar refresh token jadi access token baru + refresh token baru (stateless)
+// @Description Tukar refresh token jadi access token baru + refresh token baru. Refresh token lama langsung tidak berlaku (rotasi);
+// @Description memakai refresh token yang sudah dirotasi dianggap pencurian token dan seluruh sesi (family) itu dicabut.
 // @Tags Auth
 // @Accept json
 // @Produce json
@@ -133,12 +158,18 @@ func (s *AuthService) Refresh(c *fiber.Ctx) error {
 		return c.Status(500).JSON(fiber.Map{"status": "error", "message": "failed to load permissions"})
 	}
 
-	newAccess, err := utils.GenerateToken(info.ID, info.RoleName, perms)
+	newAccess, err := utils.GenerateToken(info.ID, info.RoleName, perms, claims.FamilyID)
 	if err != nil {
 		return c.Status(500).JSON(fiber.Map{"status": "error", "message": "failed to generate token"})
 	}
 
-	newRefresh, err := utils.GenerateRefreshToken(info.ID)
+	newRefresh, err := s.Tokens.Rotate(claims)
+	if err == repository.ErrRefreshTokenReused {
+		return c.Status(401).JSON(fiber.Map{"status": "error", "message": "refresh token reuse detected, please login again"})
+	}
+	if err == repository.ErrRefreshTokenInvalid {
+		return c.Status(401).JSON(fiber.Map{"status": "error", "message": "invalid refresh token"})
+	}
 	if err != nil {
 		return c.Status(500).JSON(fiber.Map{"status": "error", "message": "failed to generate refresh token"})
 	}
@@ -154,20 +185,174 @@ func (s *AuthService) Refresh(c *fiber.Ctx) error {
 
 // Logout godoc
 // @Summary Logout
-// @Description Stateless logout (server tidak revoke token). Client wajib hapus token.
+// @Description Cabut access token ini dan refresh token sesi login yang sama. Sesi di perangkat lain tetap aktif.
 // @Tags Auth
 // @Security BearerAuth
 // @Produce json
 // @Success 200 {object} model.LogoutResponse
 // @Failure 401 {object} model.AuthUnauthorizedResponse
+// @Failure 500 {object} model.AuthErrorResponse
 // @Router /auth/logout [post]
 func (s *AuthService) Logout(c *fiber.Ctx) error {
+	token, ok := c.Locals("user").(*jwt.Token)
+	if !ok || token == nil {
+		return c.Status(401).JSON(fiber.Map{"status": "error", "message": "unauthorized"})
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return c.Status(401).JSON(fiber.Map{"status": "error", "message": "unauthorized"})
+	}
+
+	if err := s.Tokens.RevokeSession(claims); err != nil {
+		return c.Status(500).JSON(fiber.Map{"status": "error", "message": "failed to revoke token"})
+	}
 	return c.JSON(model.LogoutResponse{
 		Status: "success",
 		Data:   model.LogoutResponseData{Message: "logout success"},
 	})
 }
 
+// LogoutAll godoc
+// @Summary Logout all devices
+// @Description Cabut semua access token dan refresh token milik user (semua perangkat), termasuk token ini.
+// @Tags Auth
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} model.LogoutResponse
+// @Failure 401 {object} model.AuthUnauthorizedResponse
+// @Failure 500 {object} model.AuthErrorResponse
+// @Router /auth/logout-all [post]
+func (s *AuthService) LogoutAll(c *fiber.Ctx) error {
+	_, userID, ok := getRoleAndUserID(c)
+	if !ok {
+		return c.Status(401).JSON(fiber.Map{"status": "error", "message": "unauthorized"})
+	}
+
+	if err := s.Tokens.RevokeUser(userID, model.TokenRevokeLogoutAll); err != nil {
+		return c.Status(500).JSON(fiber.Map{"status": "error", "message": "failed to revoke tokens"})
+	}
+	return c.JSON(model.LogoutResponse{
+		Status: "success",
+		Data:   model.LogoutResponseData{Message: "logged out from all devices"},
+	})
+}
+
+// ForgotPassword godoc
+// @Summary Forgot password
+// @Description Kirim tautan reset password ke email user (username atau email). Respons selalu sama
+// @Description walaupun user tidak ada supaya tidak bisa dipakai menebak akun. Token berlaku PASSWORD_RESET_TTL_MINUTES.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param body body model.ForgotPasswordRequest true "Forgot password payload"
+// @Success 202 {object} model.PasswordResetResponse
+// @Failure 400 {object} model.AuthErrorResponse
+// @Failure 503 {object} model.AuthErrorResponse
+// @Router /auth/password/forgot [post]
+func (s *AuthService) ForgotPassword(c *fiber.Ctx) error {
+	if s.Email == nil || s.ResetURL == "" {
+		return c.Status(503).JSON(fiber.Map{"status": "error", "message": "password reset is not available"})
+	}
+
+	var req model.ForgotPasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"status": "error", "message": "invalid request"})
+	}
+	req.Identifier = strings.TrimSpace(req.Identifier)
+	if req.Identifier == "" {
+		return c.Status(400).JSON(fiber.Map{"status": "error", "message": "identifier is required"})
+	}
+
+	accepted := model.PasswordResetResponse{
+		Status: "success",
+		Data:   model.PasswordResetResponseData{Message: "if the account exists, a reset link has been sent to its email"},
+	}
+
+	user, err := s.Repo.GetUserByIdentifier(req.Identifier)
+	if err != nil || !user.IsActive {
+		return c.Status(202).JSON(accepted)
+	}
+
+	// kegagalan setelah user ditemukan hanya dicatat di log: respons berbeda akan membocorkan
+	// bahwa akun tersebut ada
+	token, err := newResetToken()
+	if err != nil {
+		log.Println("password reset token:", err)
+		return c.Status(202).JSON(accepted)
+	}
+	if err := s.Repo.CreatePasswordResetToken(user.ID, hashResetToken(token), time.Now().Add(s.ResetTTL)); err != nil {
+		log.Println("password reset token:", user.ID, err)
+		return c.Status(202).JSON(accepted)
+	}
+
+	// user tanpa email -> tidak ada yang dikirim, respons tetap sama
+	err = s.Email.SendPasswordReset(user.ID, s.ResetURL+"?token="+url.QueryEscape(token), s.ResetTTL)
+	if err != nil && err != repository.ErrNotFoundOrForbidden {
+		log.Println("password reset email:", user.ID, err)
+	}
+	return c.Status(202).JSON(accepted)
+}
+
+// ResetPassword godoc
+// @Summary Reset password
+// @Description Ganti password dengan token dari email lupa password. Token sekali pakai; semua sesi login user dicabut.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param body body model.ResetPasswordRequest true "Reset password payload"
+// @Success 200 {object} model.PasswordResetResponse
+// @Failure 400 {object} model.AuthErrorResponse
+// @Failure 500 {object} model.AuthErrorResponse
+// @Router /auth/password/reset [post]
+func (s *AuthService) ResetPassword(c *fiber.Ctx) error {
+	var req model.ResetPasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"status": "error", "message": "invalid request"})
+	}
+	req.Token = strings.TrimSpace(req.Token)
+	req.NewPassword = strings.TrimSpace(req.NewPassword)
+	if req.Token == "" || req.NewPassword == "" {
+		return c.Status(400).JSON(fiber.Map{"status": "error", "message": "token and newPassword are required"})
+	}
+
+	hash, err := utils.HashPassword(req.NewPassword)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"status": "error", "message": "failed to hash password"})
+	}
+
+	userID, ok, err := s.Repo.ResetPassword(hashResetToken(req.Token), hash)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"status": "error", "message": "failed to reset password"})
+	}
+	if !ok {
+		return c.Status(400).JSON(fiber.Map{"status": "error", "message": "invalid or expired reset token"})
+	}
+
+	// sesi lama (mungkin milik orang yang tahu password lama) diputus
+	if err := s.Tokens.RevokeUser(userID, model.TokenRevokePasswordReset); err != nil {
+		return c.Status(500).JSON(fiber.Map{"status": "error", "message": "failed to revoke tokens"})
+	}
+	return c.JSON(model.PasswordResetResponse{
+		Status: "success",
+		Data:   model.PasswordResetResponseData{Message: "password has been reset"},
+	})
+}
+
+// newResetToken token acak 256 bit (hex) yang dikirim lewat email
+func newResetToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// hashResetToken yang disimpan di DB hanya hash token
+func hashResetToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
 // Profile godoc
 // @Summary Get current profile
 // @Description Ambil data user dari access token (Bearer)
@@ -0,0 +1,215 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"project_uas/app/model"
+	"project_uas/app/repository"
+	"project_uas/mailer"
+)
+
+//
+// ===== EMAIL =====
+// Email dirender saat notifikasi dibuat lalu disimpan di email_outbox. EmailDispatcher mengirim
+// dengan retry + backoff; setelah EMAIL_MAX_ATTEMPTS gagal email dipindah ke email_dead_letters.
+//
+
+// emailTemplates template per tipe event; event tanpa template (komentar) tidak dikirim via email
+var emailTemplates = map[string]string{
+	model.EventAchievementSubmitted:        mailer.TemplateSubmitted,
+	model.EventAchievementAwaitingApproval: mailer.TemplateAwaitingApproval,
+	model.EventAchievementVerified:         mailer.TemplateVerified,
+	model.EventAchievementRejected:         mailer.TemplateRejected,
+}
+
+// EmailChannel NotificationChannel "email"
+type EmailChannel struct {
+	Repo          repository.EmailRepository
+	Notifications repository.NotificationRepository
+	Dispatcher    *EmailDispatcher
+	// BaseURL APP_BASE_URL, dipakai untuk tautan ke prestasi (kosong = tanpa tautan)
+	BaseURL string
+}
+
+func NewEmailChannel(repo repository.EmailRepository, notifications repository.NotificationRepository, dispatcher *EmailDispatcher) *EmailChannel {
+	return &EmailChannel{
+		Repo:          repo,
+		Notifications: notifications,
+		Dispatcher:    dispatcher,
+		BaseURL:       strings.TrimRight(os.Getenv("APP_BASE_URL"), "/"),
+	}
+}
+
+func (c *EmailChannel) Name() string { return "email" }
+
+func (c *EmailChannel) Send(_ context.Context, n model.Notification) error {
+	tmpl, ok := emailTemplates[n.Type]
+	if !ok {
+		return nil
+	}
+
+	to, ok, err := c.Notifications.EmailRecipient(n.UserID)
+	if err != nil || !ok {
+		return err
+	}
+	if !to.Preferences.EmailAllowed(n.Type) {
+		return nil
+	}
+
+	data := mailer.TemplateData{RecipientName: to.Name, Note: n.Note, Reviewer: n.ForReviewer}
+	if n.ActorName != nil {
+		data.ActorName = *n.ActorName
+	}
+	if c.BaseURL != "" && n.AchievementID != nil {
+		data.URL = c.BaseURL + "/achievements/" + *n.AchievementID
+	}
+	return c.enqueue(to, n.ID, tmpl, data)
+}
+
+// SendPasswordReset kirim tautan reset password (AuthService.ForgotPassword); tidak mengikuti
+// opt-out karena bukan notifikasi. User nonaktif / tanpa email -> repository.ErrNotFoundOrForbidden.
+func (c *EmailChannel) SendPasswordReset(userID, resetURL string, validFor time.Duration) error {
+	to, ok, err := c.Notifications.EmailRecipient(userID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return repository.ErrNotFoundOrForbidden
+	}
+
+	data := mailer.TemplateData{RecipientName: to.Name, URL: resetURL}
+	if validFor > 0 {
+		data.ExpiresIn = formatMinutes(validFor, to.Preferences.Language)
+	}
+	return c.enqueue(to, "", mailer.TemplatePasswordReset, data)
+}
+
+func (c *EmailChannel) enqueue(to model.EmailRecipient, notificationID, tmpl string, data mailer.TemplateData) error {
+	lang := to.Preferences.Language
+	if !mailer.SupportedLanguage(lang) {
+		lang = mailer.DefaultLanguage
+	}
+	msg, err := mailer.Render(tmpl, lang, data)
+	if err != nil {
+		return err
+	}
+
+	id, err := c.Repo.Enqueue(&model.EmailDelivery{
+		UserID:         to.UserID,
+		NotificationID: notificationID,
+		ToEmail:        to.Email,
+		ToName:         to.Name,
+		Template:       tmpl,
+		Language:       lang,
+		Subject:        msg.Subject,
+		TextBody:       msg.Text,
+		HTMLBody:       msg.HTML,
+	})
+	if err != nil {
+		return err
+	}
+	// kirim sekarang tanpa menahan worker notifikasi; gagal tetap diambil ulang oleh Run
+	go c.Dispatcher.Dispatch(id)
+	return nil
+}
+
+func formatMinutes(d time.Duration, lang string) string {
+	m := int(d.Round(time.Minute) / time.Minute)
+	if lang == mailer.LanguageEN {
+		return fmt.Sprintf("%d minutes", m)
+	}
+	return fmt.Sprintf("%d menit", m)
+}
+
+// EmailDispatcher pengirim email_outbox; pola sama dengan OutboxProcessor.
+type EmailDispatcher struct {
+	Repo   repository.EmailRepository
+	Sender mailer.Sender
+	// MaxAttempts EMAIL_MAX_ATTEMPTS (default 5), setelah itu dead letter
+	MaxAttempts int
+}
+
+func NewEmailDispatcher(repo repository.EmailRepository, sender mailer.Sender) *EmailDispatcher {
+	return &EmailDispatcher{Repo: repo, Sender: sender, MaxAttempts: envInt("EMAIL_MAX_ATTEMPTS", 5)}
+}
+
+const emailSendTimeout = 30 * time.Second
+
+// Dispatch langsung mengirim satu email (gagal -> tetap pending untuk worker).
+func (d *EmailDispatcher) Dispatch(id string) {
+	if _, err := d.process(id, 1); err != nil {
+		log.Println("email dispatch:", err)
+	}
+}
+
+func (d *EmailDispatcher) ProcessPending(limit int) (int, error) {
+	return d.process("", limit)
+}
+
+// Run worker periodik sampai stop ditutup.
+func (d *EmailDispatcher) Run(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			if _, err := d.ProcessPending(50); err != nil {
+				log.Println("email worker:", err)
+			}
+		}
+	}
+}
+
+func (d *EmailDispatcher) process(id string, limit int) (int, error) {
+	emails, err := d.Repo.ClaimPending(id, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, e := range emails {
+		if err := d.send(e); err != nil {
+			d.fail(e, err)
+			continue
+		}
+		if err := d.Repo.MarkSent(e.ID); err != nil {
+			log.Println("email mark sent:", err)
+			continue
+		}
+		sent++
+	}
+	return sent, nil
+}
+
+func (d *EmailDispatcher) send(e model.EmailDelivery) error {
+	ctx, cancel := context.WithTimeout(context.Background(), emailSendTimeout)
+	defer cancel()
+	return d.Sender.Send(ctx, mailer.Message{
+		To:      e.ToEmail,
+		ToName:  e.ToName,
+		Subject: e.Subject,
+		Text:    e.TextBody,
+		HTML:    e.HTMLBody,
+	})
+}
+
+func (d *EmailDispatcher) fail(e model.EmailDelivery, cause error) {
+	if e.Attempts >= d.MaxAttempts {
+		log.Println("email dead letter:", e.ID, e.ToEmail, cause)
+		if err := d.Repo.DeadLetter(e.ID, cause); err != nil {
+			log.Println("email dead letter:", err)
+		}
+		return
+	}
+	if err := d.Repo.MarkFailed(e.ID, cause, time.Now().Add(outboxBackoff(e.Attempts))); err != nil {
+		log.Println("email mark failed:", err)
+	}
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"project_uas/app/model"
+)
+
+// EventHandler subscriber EventBus; dipanggil dari goroutine worker, bukan dari handler HTTP.
+type EventHandler func(model.AchievementEvent)
+
+// EventBus pub/sub in-process untuk event alur prestasi. Publish tidak pernah blocking:
+// event masuk buffer lalu diantar ke semua subscriber oleh worker (Run). Subscriber hanya
+// membangunkan worker yang membaca tabel antrean (notification_events, webhook_deliveries).
+type EventBus struct {
+	events chan model.AchievementEvent
+
+	mu       sync.RWMutex
+	handlers []EventHandler
+}
+
+func NewEventBus(buffer int) *EventBus {
+	if buffer <= 0 {
+		buffer = 256
+	}
+	return &EventBus{events: make(chan model.AchievementEvent, buffer)}
+}
+
+func (b *EventBus) Subscribe(h EventHandler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers = append(b.handlers, h)
+}
+
+// Publish dipanggil setelah commit. Buffer penuh -> event dibuang dan dicatat di log; notifikasi
+// dan webhook sudah tersimpan di transaksi, jadi hanya tertunda sampai tick worker berikutnya.
+func (b *EventBus) Publish(evt model.AchievementEvent) {
+	if b == nil {
+		return
+	}
+	if evt.At.IsZero() {
+		evt.At = time.Now()
+	}
+	select {
+	case b.events <- evt:
+	default:
+		log.Println("event bus full, dropping", evt.Type, evt.AchievementID)
+	}
+}
+
+// Run worker pengantar event sampai stop ditutup.
+func (b *EventBus) Run(stop <-chan struct{}) {
+	for {
+		select {
+		case <-stop:
+			return
+		case evt := <-b.events:
+			b.deliver(evt)
+		}
+	}
+}
+
+func (b *EventBus) deliver(evt model.AchievementEvent) {
+	b.mu.RLock()
+	handlers := append([]EventHandler(nil), b.handlers...)
+	b.mu.RUnlock()
+
+	for _, h := range handlers {
+		func() {
+			// satu subscriber panic tidak boleh menghentikan worker
+			defer func() {
+				if r := recover(); r != nil {
+					log.Println("event handler panic:", evt.Type, r)
+				}
+			}()
+			h(evt)
+		}()
+	}
+}
@@ -0,0 +1,291 @@
+package service
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"project_uas/app/model"
+	"project_uas/app/repository"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/lib/pq"
+)
+
+//
+// ===== SERVER-SENT EVENTS =====
+// Perubahan status dan komentar ditulis ke achievement_stream_events di transaksi perubahannya;
+// saat commit event mendapat seq (urut commit) dan di-pg_notify. StreamHub di tiap replika LISTEN lalu menyiarkan ke client SSE lokal;
+// filter scope role dilakukan per koneksi (sama dengan GetAchievements).
+//
+
+const (
+	streamClientBuffer = 64
+	streamReplayLimit  = 1000
+	streamKeepAlive    = 15 * time.Second
+	// daftar mahasiswa bimbingan dosen wali dimuat ulang secara berkala
+	streamScopeRefresh = time.Minute
+)
+
+// StreamHub penyiar event stream ke semua koneksi SSE di replika ini.
+type StreamHub struct {
+	Repo repository.AchievementStreamRepository
+
+	mu      sync.Mutex
+	clients map[chan model.StreamEvent]struct{}
+	lastID  int64
+}
+
+func NewStreamHub(repo repository.AchievementStreamRepository) *StreamHub {
+	return &StreamHub{Repo: repo, clients: map[chan model.StreamEvent]struct{}{}}
+}
+
+// Subscribe channel event baru; client lambat (buffer penuh) diputus dan channel ditutup,
+// client SSE lalu reconnect dengan Last-Event-ID.
+func (h *StreamHub) Subscribe() (<-chan model.StreamEvent, func()) {
+	ch := make(chan model.StreamEvent, streamClientBuffer)
+	h.mu.Lock()
+	h.clients[ch] = struct{}{}
+	h.mu.Unlock()
+
+	return ch, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		if _, ok := h.clients[ch]; ok {
+			delete(h.clients, ch)
+			close(ch)
+		}
+	}
+}
+
+func (h *StreamHub) broadcast(e model.StreamEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if e.ID > h.lastID {
+		h.lastID = e.ID
+	}
+	for ch := range h.clients {
+		select {
+		case ch <- e:
+		default:
+			delete(h.clients, ch)
+			close(ch)
+		}
+	}
+}
+
+// Run membaca notifikasi LISTEN sampai stop ditutup. Notifikasi nil (koneksi tersambung
+// ulang) -> kejar event yang terlewat dari tabel. Event lama dipangkas tiap jam.
+func (h *StreamHub) Run(notify <-chan *pq.Notification, stop <-chan struct{}) {
+	prune := time.NewTicker(time.Hour)
+	defer prune.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case n := <-notify:
+			if n == nil {
+				h.catchUp()
+				continue
+			}
+			id, err := strconv.ParseInt(n.Extra, 10, 64)
+			if err != nil {
+				continue
+			}
+			e, found, err := h.Repo.GetByID(id)
+			if err != nil {
+				log.Println("event stream:", err)
+				continue
+			}
+			if found {
+				h.broadcast(*e)
+			}
+		case <-prune.C:
+			if _, err := h.Repo.Prune(); err != nil {
+				log.Println("event stream prune:", err)
+			}
+		}
+	}
+}
+
+func (h *StreamHub) catchUp() {
+	h.mu.Lock()
+	after := h.lastID
+	h.mu.Unlock()
+	if after == 0 {
+		return
+	}
+
+	events, err := h.Repo.ListAfter(after, streamReplayLimit)
+	if err != nil {
+		log.Println("event stream catch up:", err)
+		return
+	}
+	for _, e := range events {
+		h.broadcast(e)
+	}
+}
+
+// EventStreamService handler GET /events
+type EventStreamService struct {
+	Hub             *StreamHub
+	Repo            repository.AchievementStreamRepository
+	AchievementRepo repository.AchievementRepository
+}
+
+func NewEventStreamService(hub *StreamHub, repo repository.AchievementStreamRepository, achievementRepo repository.AchievementRepository) *EventStreamService {
+	return &EventStreamService{Hub: hub, Repo: repo, AchievementRepo: achievementRepo}
+}
+
+// streamScope filter event per koneksi: Admin semua, Mahasiswa miliknya, Dosen Wali mahasiswa bimbingan
+type streamScope struct {
+	role       string
+	userID     string
+	studentIDs map[string]bool
+	loadedAt   time.Time
+}
+
+func (s *EventStreamService) loadScope(sc *streamScope) error {
+	var ids []string
+	switch sc.role {
+	case model.RoleAdmin:
+		return nil
+	case model.RoleStudent:
+		id, found, err := s.AchievementRepo.GetStudentIDByUserID(sc.userID)
+		if err != nil {
+			return err
+		}
+		if found {
+			ids = []string{id}
+		}
+	case model.RoleLecturer:
+		advisees, err := s.AchievementRepo.GetAdviseeStudentIDs(sc.userID)
+		if err != nil {
+			return err
+		}
+		ids = advisees
+	}
+
+	sc.studentIDs = make(map[string]bool, len(ids))
+	for _, id := range ids {
+		sc.studentIDs[id] = true
+	}
+	sc.loadedAt = time.Now()
+	return nil
+}
+
+func (s *EventStreamService) allows(sc *streamScope, e model.StreamEvent) bool {
+	if sc.role == model.RoleAdmin {
+		return true
+	}
+	if sc.role == model.RoleLecturer && time.Since(sc.loadedAt) > streamScopeRefresh {
+		if err := s.loadScope(sc); err != nil {
+			log.Println("event stream scope:", err)
+		}
+	}
+	return sc.studentIDs[e.StudentID]
+}
+
+// StreamEvents godoc
+// @Summary Live achievement events (Server-Sent Events)
+// @Description Stream text/event-stream berisi perubahan status (event: status) dan komentar baru (event: comment)
+// @Description dengan scope yang sama seperti GET /achievements. Tiap event punya id; kirim header Last-Event-ID
+// @Description saat reconnect untuk menerima event yang terlewat (maks 24 jam terakhir).
+// @Description Butuh header Authorization, jadi pakai EventSource berbasis fetch (EventSource bawaan browser tidak bisa mengirim header).
+// @Tags Events
+// @Security BearerAuth
+// @Produce text/event-stream
+// @Param Last-Event-ID header int false "Lanjut setelah event ini"
+// @Success 200 {object} model.StreamEvent "data tiap event SSE"
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /events [get]
+func (s *EventStreamService) StreamEvents(c *fiber.Ctx) error {
+	role, userID, ok := getRoleAndUserID(c)
+	if !ok {
+		return c.SendStatus(fiber.StatusUnauthorized)
+	}
+	if role != model.RoleAdmin && role != model.RoleStudent && role != model.RoleLecturer {
+		return c.Status(403).JSON(fiber.Map{"message": "forbidden"})
+	}
+
+	sc := &streamScope{role: role, userID: userID}
+	if err := s.loadScope(sc); err != nil {
+		return c.Status(500).JSON(fiber.Map{"message": "failed to resolve scope"})
+	}
+	lastID, _ := strconv.ParseInt(c.Get("Last-Event-ID"), 10, 64)
+
+	// subscribe sebelum replay supaya event di antara replay dan live tidak hilang
+	events, unsubscribe := s.Hub.Subscribe()
+
+	c.Set("Content-Type", "text/event-stream")
+	c.Set("Cache-Control", "no-cache")
+	c.Set("Connection", "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer unsubscribe()
+
+		fmt.Fprint(w, "retry: 3000\n\n")
+		if err := w.Flush(); err != nil {
+			return
+		}
+
+		replayed := map[int64]bool{}
+		if lastID > 0 {
+			backlog, err := s.Repo.ListAfter(lastID, streamReplayLimit)
+			if err != nil {
+				log.Println("event stream replay:", err)
+			}
+			for _, e := range backlog {
+				replayed[e.ID] = true
+				if s.allows(sc, e) && writeStreamEvent(w, e) != nil {
+					return
+				}
+			}
+			if w.Flush() != nil {
+				return
+			}
+		}
+
+		keepAlive := time.NewTicker(streamKeepAlive)
+		defer keepAlive.Stop()
+
+		for {
+			select {
+			case e, open := <-events:
+				if !open {
+					return
+				}
+				if replayed[e.ID] || !s.allows(sc, e) {
+					continue
+				}
+				if writeStreamEvent(w, e) != nil || w.Flush() != nil {
+					return
+				}
+			case <-keepAlive.C:
+				// komentar SSE: menjaga koneksi lewat proxy dan mendeteksi client yang sudah pergi
+				fmt.Fprint(w, ": ping\n\n")
+				if w.Flush() != nil {
+					return
+				}
+			}
+		}
+	})
+	return nil
+}
+
+func writeStreamEvent(w *bufio.Writer, e model.StreamEvent) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", e.ID, e.Type, data)
+	return err
+}
@@ -1,17 +1,21 @@
 package service
 
 import (
+	"errors"
+
+	"project_uas/app/model"
 	"project_uas/app/repository"
 
 	"github.com/gofiber/fiber/v2"
 )
 
 type LecturerService struct {
-	Repo repository.LecturerRepository
+	Repo           repository.LecturerRepository
+	DelegationRepo repository.ReviewDelegationRepository
 }
 
-func NewLecturerService(repo repository.LecturerRepository) *LecturerService {
-	return &LecturerService{Repo: repo}
+func NewLecturerService(repo repository.LecturerRepository, delegationRepo repository.ReviewDelegationRepository) *LecturerService {
+	return &LecturerService{Repo: repo, DelegationRepo: delegationRepo}
 }
 
 // GetLecturers godoc
@@ -61,3 +65,91 @@ func (s *LecturerService) GetAdvisees(c *fiber.Ctx) error {
 	}
 	return c.JSON(fiber.Map{"data": data})
 }
+
+//
+// ===== REVIEW DELEGATION =====
+//
+
+// CreateDelegation godoc
+// @Summary Delegate pending reviews to a substitute lecturer
+// @Description Selama startsOn..endsOn (inklusif) dosen pengganti bisa melihat, verify, dan reject prestasi submitted
+// @Description milik mahasiswa bimbingan dosen ini. History mencatat dosen wali asli sebagai onBehalfOf.
+// @Tags Lecturers
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Lecturer ID (uuid)"
+// @Param body body model.ReviewDelegationRequest true "Delegation payload"
+// @Success 201 {object} model.ReviewDelegationResponse
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.AuthUnauthorizedResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 409 {object} model.ErrorResponse
+// @Failure 422 {object} model.ValidationErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /lecturers/{id}/delegations [post]
+func (s *LecturerService) CreateDelegation(c *fiber.Ctx) error {
+	lecturerID := c.Params("id")
+	_, userID, _ := getRoleAndUserID(c)
+
+	var body model.ReviewDelegationRequest
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(400).JSON(fiber.Map{"message": "invalid body"})
+	}
+	if errs := body.Validate(lecturerID); len(errs) > 0 {
+		return respondValidationError(c, errs)
+	}
+
+	d, err := s.DelegationRepo.Create(lecturerID, body, userID)
+	switch {
+	case errors.Is(err, repository.ErrLecturerNotFound), errors.Is(err, repository.ErrSubstituteNotFound):
+		return c.Status(404).JSON(fiber.Map{"message": err.Error()})
+	case errors.Is(err, repository.ErrDelegationOverlap):
+		return c.Status(409).JSON(fiber.Map{"message": err.Error()})
+	case err != nil:
+		return c.Status(500).JSON(fiber.Map{"message": "failed to create delegation"})
+	}
+	return c.Status(201).JSON(fiber.Map{"data": d})
+}
+
+// GetDelegations godoc
+// @Summary List review delegations of a lecturer
+// @Description Delegasi dari maupun ke dosen ini (termasuk yang sudah lewat / dicabut).
+// @Tags Lecturers
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Lecturer ID (uuid)"
+// @Success 200 {object} model.ReviewDelegationListResponse
+// @Failure 401 {object} model.AuthUnauthorizedResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /lecturers/{id}/delegations [get]
+func (s *LecturerService) GetDelegations(c *fiber.Ctx) error {
+	data, err := s.DelegationRepo.ListByLecturer(c.Params("id"))
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"message": "failed to fetch delegations"})
+	}
+	return c.JSON(fiber.Map{"data": data})
+}
+
+// RevokeDelegation godoc
+// @Summary Revoke review delegation
+// @Tags Lecturers
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Lecturer ID (uuid)"
+// @Param delegationId path string true "Delegation ID"
+// @Success 200 {object} model.MessageResponse
+// @Failure 401 {object} model.AuthUnauthorizedResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /lecturers/{id}/delegations/{delegationId} [delete]
+func (s *LecturerService) RevokeDelegation(c *fiber.Ctx) error {
+	revoked, err := s.DelegationRepo.Revoke(c.Params("id"), c.Params("delegationId"))
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"message": "failed to revoke delegation"})
+	}
+	if !revoked {
+		return c.Status(404).JSON(fiber.Map{"message": "delegation not found"})
+	}
+	return c.JSON(fiber.Map{"message": "delegation revoked"})
+}
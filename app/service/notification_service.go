@@ -0,0 +1,374 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"project_uas/app/model"
+	"project_uas/app/repository"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// NotificationChannel kanal pengiriman tambahan (mis. email) setelah notifikasi in-app tersimpan.
+type NotificationChannel interface {
+	Name() string
+	Send(ctx context.Context, n model.Notification) error
+}
+
+// NotificationService mengubah notification_events (ditulis di transaksi transisi / komentar) menjadi
+// notifikasi in-app per penerima lalu meneruskannya ke semua channel. Berjalan di worker Run;
+// EventBus hanya membangunkannya.
+type NotificationService struct {
+	Repo     repository.NotificationRepository
+	Channels []NotificationChannel
+	// MaxAttempts NOTIFICATION_MAX_ATTEMPTS (default 10), setelah itu event failed
+	MaxAttempts int
+
+	wake chan struct{}
+}
+
+func NewNotificationService(repo repository.NotificationRepository, channels ...NotificationChannel) *NotificationService {
+	return &NotificationService{
+		Repo:        repo,
+		Channels:    channels,
+		MaxAttempts: envInt("NOTIFICATION_MAX_ATTEMPTS", 10),
+		wake:        make(chan struct{}, 1),
+	}
+}
+
+// NotificationChannelsFromEnv NOTIFICATION_CHANNELS: daftar dipisah koma, kosong = hanya in-app.
+// Tersedia: log, plus channel di available yang sudah dikonfigurasi (mis. email bila MAIL_DRIVER diisi).
+func NotificationChannelsFromEnv(available ...NotificationChannel) ([]NotificationChannel, error) {
+	var channels []NotificationChannel
+next:
+	for _, name := range splitCSV(os.Getenv("NOTIFICATION_CHANNELS")) {
+		name = strings.ToLower(name)
+		if name == "log" {
+			channels = append(channels, LogChannel{})
+			continue
+		}
+		for _, ch := range available {
+			if ch.Name() == name {
+				channels = append(channels, ch)
+				continue next
+			}
+		}
+		return nil, fmt.Errorf("unknown or unconfigured notification channel %q", name)
+	}
+	return channels, nil
+}
+
+// LogChannel tulis notifikasi ke log (development)
+type LogChannel struct{}
+
+func (LogChannel) Name() string { return "log" }
+
+func (LogChannel) Send(_ context.Context, n model.Notification) error {
+	log.Printf("notification to=%s type=%s title=%q", n.UserID, n.Type, n.Title)
+	return nil
+}
+
+const notificationSendTimeout = 30 * time.Second
+
+// HandleEvent dipasang lewat EventBus.Subscribe. Event sudah tersimpan di notification_events;
+// event bus hanya membangunkan Run supaya notifikasi tidak menunggu tick berikutnya.
+func (s *NotificationService) HandleEvent(evt model.AchievementEvent) {
+	if evt.Type == model.EventAchievementCreated || evt.Type == model.EventAchievementDeleted {
+		return
+	}
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (s *NotificationService) ProcessPending(limit int) (int, error) {
+	events, err := s.Repo.ClaimEvents(limit)
+	if err != nil {
+		return 0, err
+	}
+
+	done := 0
+	for _, e := range events {
+		if err := s.handle(e); err != nil {
+			s.fail(e, err)
+			continue
+		}
+		if err := s.Repo.MarkEventDone(e.ID); err != nil {
+			log.Println("notification mark done:", err)
+			continue
+		}
+		done++
+	}
+	return done, nil
+}
+
+// Run worker periodik sampai stop ditutup; juga langsung jalan saat dibangunkan HandleEvent.
+func (s *NotificationService) Run(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		case <-s.wake:
+		}
+		if _, err := s.ProcessPending(50); err != nil {
+			log.Println("notification worker:", err)
+		}
+	}
+}
+
+func (s *NotificationService) fail(e model.NotificationEvent, cause error) {
+	var retryAt *time.Time
+	if e.Attempts < s.MaxAttempts {
+		t := time.Now().Add(outboxBackoff(e.Attempts))
+		retryAt = &t
+	} else {
+		log.Println("notification event failed:", e.ID, e.Event.Type, e.Event.AchievementID, cause)
+	}
+	if err := s.Repo.MarkEventFailed(e.ID, cause, retryAt); err != nil {
+		log.Println("notification mark failed:", err)
+	}
+}
+
+// handle buat notifikasi untuk semua penerima satu event. Gagal di salah satu penerima -> event
+// dicoba ulang; penerima yang sudah dapat dilewati (unik per event + user), channel tidak dikirim ulang.
+func (s *NotificationService) handle(e model.NotificationEvent) error {
+	evt := e.Event
+	recipients, err := s.Repo.Recipients(evt.AchievementID)
+	if err != nil {
+		return err
+	}
+	if evt.Type == model.EventAchievementAwaitingApproval {
+		if recipients.CurrentStage == "" {
+			// tahap sudah diputuskan sebelum event diproses; event berikutnya yang memberi tahu
+			return nil
+		}
+		evt.Note = recipients.CurrentStage
+	}
+
+	var firstErr error
+	for _, userID := range eventRecipients(evt, recipients) {
+		forReviewer := userID != recipients.StudentUserID
+		title, message := notificationContent(evt, forReviewer)
+		refID, actorID := evt.AchievementID, evt.ActorUserID
+		n := model.Notification{
+			UserID:        userID,
+			Type:          evt.Type,
+			Title:         title,
+			Message:       message,
+			AchievementID: &refID,
+			ActorID:       &actorID,
+		}
+		created, err := s.Repo.CreateForEvent(e.ID, &n)
+		if err != nil {
+			log.Println("notification create:", evt.Type, userID, err)
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		if !created {
+			continue
+		}
+		n.Note = evt.Note
+		n.ForReviewer = forReviewer
+		s.fanOut(n)
+	}
+	return firstErr
+}
+
+func (s *NotificationService) fanOut(n model.Notification) {
+	for _, ch := range s.Channels {
+		ctx, cancel := context.WithTimeout(context.Background(), notificationSendTimeout)
+		if err := ch.Send(ctx, n); err != nil {
+			log.Println("notification channel", ch.Name()+":", n.ID, err)
+		}
+		cancel()
+	}
+}
+
+// eventRecipients siapa yang diberi tahu; aktor sendiri tidak pernah dapat notifikasi
+func eventRecipients(evt model.AchievementEvent, r model.NotificationRecipients) []string {
+	var users []string
+	switch evt.Type {
+	case model.EventAchievementSubmitted:
+		users = r.AdvisorUserIDs
+	case model.EventAchievementAwaitingApproval:
+		users = append([]string{r.StudentUserID}, r.ReviewerUserIDs...)
+	case model.EventAchievementVerified, model.EventAchievementRejected:
+		users = []string{r.StudentUserID}
+	case model.EventAchievementCommented:
+		users = append([]string{r.StudentUserID}, r.AdvisorUserIDs...)
+	}
+
+	seen := map[string]bool{evt.ActorUserID: true}
+	out := []string{}
+	for _, u := range users {
+		if u != "" && !seen[u] {
+			seen[u] = true
+			out = append(out, u)
+		}
+	}
+	return out
+}
+
+// notificationContent forReviewer dipakai event yang penerimanya campuran (mahasiswa + reviewer)
+func notificationContent(evt model.AchievementEvent, forReviewer bool) (title, message string) {
+	switch evt.Type {
+	case model.EventAchievementSubmitted:
+		return "Achievement awaiting verification", "A student submitted an achievement for your verification."
+	case model.EventAchievementAwaitingApproval:
+		if forReviewer {
+			return "Achievement awaiting your approval", "An achievement is awaiting approval at stage: " + evt.Note
+		}
+		return "Achievement awaiting approval", "Your achievement was approved and is awaiting the next stage: " + evt.Note
+	case model.EventAchievementVerified:
+		return "Achievement verified", "Your achievement has been verified."
+	case model.EventAchievementRejected:
+		return "Achievement rejected", "Your achievement was rejected: " + evt.Note
+	case model.EventAchievementCommented:
+		return "New comment on achievement", truncateRunes(evt.Note, 200)
+	}
+	return evt.Type, ""
+}
+
+func truncateRunes(s string, max int) string {
+	if utf8.RuneCountInString(s) <= max {
+		return s
+	}
+	return string([]rune(s)[:max]) + "…"
+}
+
+//
+// ===== HTTP =====
+//
+
+// GetNotifications godoc
+// @Summary List my notifications
+// @Description Notifikasi in-app milik user yang login, terbaru dulu. meta.unread = jumlah belum dibaca.
+// @Tags Notifications
+// @Security BearerAuth
+// @Produce json
+// @Param unread query bool false "Hanya yang belum dibaca"
+// @Param limit query int false "Limit (maks 100)" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} model.NotificationListResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /notifications [get]
+func (s *NotificationService) GetNotifications(c *fiber.Ctx) error {
+	_, userID, ok := getRoleAndUserID(c)
+	if !ok {
+		return c.SendStatus(fiber.StatusUnauthorized)
+	}
+
+	limit := c.QueryInt("limit", 20)
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	offset := c.QueryInt("offset", 0)
+	if offset < 0 {
+		offset = 0
+	}
+
+	items, meta, err := s.Repo.ListByUser(userID, c.QueryBool("unread", false), limit, offset)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"message": "failed to fetch notifications"})
+	}
+	return c.JSON(model.NotificationListResponse{Data: items, Meta: meta})
+}
+
+// MarkNotificationRead godoc
+// @Summary Mark notification as read
+// @Tags Notifications
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Notification ID"
+// @Success 200 {object} model.MessageResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /notifications/{id}/read [post]
+func (s *NotificationService) MarkNotificationRead(c *fiber.Ctx) error {
+	_, userID, ok := getRoleAndUserID(c)
+	if !ok {
+		return c.SendStatus(fiber.StatusUnauthorized)
+	}
+
+	found, err := s.Repo.MarkRead(userID, c.Params("id"))
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"message": "failed to update notification"})
+	}
+	if !found {
+		return c.Status(404).JSON(fiber.Map{"message": "notification not found"})
+	}
+	return c.JSON(fiber.Map{"message": "notification marked as read"})
+}
+
+// GetNotificationPreferences godoc
+// @Summary Get my notification preferences
+// @Description Opt-out email (semua atau per tipe event) dan bahasa email. Default: email aktif, bahasa id.
+// @Tags Notifications
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} model.NotificationPreferencesResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /notifications/preferences [get]
+func (s *NotificationService) GetNotificationPreferences(c *fiber.Ctx) error {
+	_, userID, ok := getRoleAndUserID(c)
+	if !ok {
+		return c.SendStatus(fiber.StatusUnauthorized)
+	}
+
+	prefs, err := s.Repo.GetPreferences(userID)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"message": "failed to fetch notification preferences"})
+	}
+	return c.JSON(fiber.Map{"data": prefs})
+}
+
+// UpdateNotificationPreferences godoc
+// @Summary Update my notification preferences
+// @Description Mengganti seluruh preferensi. emailOptOut berisi tipe event (achievement.submitted, achievement.awaiting_approval, achievement.verified, achievement.rejected); language id | en.
+// @Tags Notifications
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param body body model.NotificationPreferencesRequest true "Preferensi notifikasi"
+// @Success 200 {object} model.NotificationPreferencesResponse
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 422 {object} model.ValidationErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /notifications/preferences [put]
+func (s *NotificationService) UpdateNotificationPreferences(c *fiber.Ctx) error {
+	_, userID, ok := getRoleAndUserID(c)
+	if !ok {
+		return c.SendStatus(fiber.StatusUnauthorized)
+	}
+
+	var body model.NotificationPreferencesRequest
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(400).JSON(fiber.Map{"message": "invalid request body"})
+	}
+	if errs := body.Validate(); len(errs) > 0 {
+		return respondValidationError(c, errs)
+	}
+
+	prefs, err := s.Repo.SavePreferences(userID, body.Preferences())
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"message": "failed to update notification preferences"})
+	}
+	return c.JSON(fiber.Map{"data": prefs})
+}
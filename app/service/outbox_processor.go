@@ -0,0 +1,152 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"project_uas/app/model"
+	"project_uas/app/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// OutboxProcessor menerapkan entry achievement_outbox ke MongoDB (saga sisi Mongo).
+// Postgres adalah sumber kebenaran: entry ditulis di transaksi yang sama dengan
+// achievement_references, lalu diterapkan di sini secara idempotent dengan retry.
+type OutboxProcessor struct {
+	Repo      repository.OutboxRepository
+	MongoRepo repository.AchievementMongoRepository
+
+	// MaxAttempts OUTBOX_MAX_ATTEMPTS (default 50), setelah itu entry failed dan antrean reference lanjut
+	MaxAttempts int
+}
+
+func NewOutboxProcessor(repo repository.OutboxRepository, mongoRepo repository.AchievementMongoRepository) *OutboxProcessor {
+	return &OutboxProcessor{Repo: repo, MongoRepo: mongoRepo, MaxAttempts: envInt("OUTBOX_MAX_ATTEMPTS", 50)}
+}
+
+// Dispatch langsung memproses entry milik satu reference (dipanggil setelah commit di handler).
+// Gagal di sini tidak masalah: entry tetap pending dan diambil worker.
+func (p *OutboxProcessor) Dispatch(refID string) {
+	if _, err := p.process(refID, 10); err != nil {
+		log.Println("outbox dispatch:", err)
+	}
+}
+
+// ProcessPending memproses batch entry pending dari semua reference.
+func (p *OutboxProcessor) ProcessPending(limit int) (int, error) {
+	return p.process("", limit)
+}
+
+// Run worker periodik sampai stop ditutup.
+func (p *OutboxProcessor) Run(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			if _, err := p.ProcessPending(100); err != nil {
+				log.Println("outbox worker:", err)
+			}
+		}
+	}
+}
+
+// process klaim berulang: tiap klaim hanya berisi entry terdepan per reference, entry berikutnya
+// baru bisa diklaim setelah yang sebelumnya selesai. Entry gagal dijadwalkan ulang (backoff) dan
+// menahan entry sesudahnya; setelah MaxAttempts entry dipindah ke status failed supaya satu entry
+// rusak tidak menahan antrean reference (dan webhook-nya) selamanya.
+func (p *OutboxProcessor) process(refID string, limit int) (int, error) {
+	done := 0
+	for done < limit {
+		entries, err := p.Repo.ClaimPending(refID, limit-done)
+		if err != nil {
+			return done, err
+		}
+
+		progressed := false
+		for _, e := range entries {
+			if err := applyOutboxEntry(p.MongoRepo, e); err != nil {
+				p.fail(e, err)
+				continue
+			}
+			if err := p.Repo.MarkDone(e.ID); err != nil {
+				log.Println("outbox mark done:", err)
+				continue
+			}
+			done++
+			progressed = true
+		}
+		if !progressed {
+			break
+		}
+	}
+	return done, nil
+}
+
+func (p *OutboxProcessor) fail(e model.OutboxEntry, cause error) {
+	var retryAt *time.Time
+	if e.Attempts < p.MaxAttempts {
+		t := time.Now().Add(outboxBackoff(e.Attempts))
+		retryAt = &t
+	} else {
+		log.Println("outbox dead letter:", e.ID, e.AchievementRefID, e.Operation, cause)
+	}
+	if err := p.Repo.MarkFailed(e.ID, cause, retryAt); err != nil {
+		log.Println("outbox mark failed:", err)
+	}
+}
+
+// applyOutboxEntry menerapkan satu entry ke Mongo; dipakai worker dan replay reconcile.
+func applyOutboxEntry(mongoRepo repository.AchievementMongoRepository, e model.OutboxEntry) error {
+	oid, err := primitive.ObjectIDFromHex(e.MongoAchievementID)
+	if err != nil {
+		return err
+	}
+
+	switch e.Operation {
+	case model.OutboxCreateDetail:
+		var doc model.AchievementMongo
+		if err := bson.UnmarshalExtJSON(e.Payload, true, &doc); err != nil {
+			return err
+		}
+		doc.ID = oid
+		return mongoRepo.InsertIfAbsent(&doc)
+	case model.OutboxMarkDeleted:
+		return mongoRepo.MarkDeleted(oid)
+	case model.OutboxSetPoints:
+		var body struct {
+			Points int `json:"points"`
+		}
+		if err := json.Unmarshal(e.Payload, &body); err != nil {
+			return err
+		}
+		return mongoRepo.Update(oid, map[string]interface{}{"points": body.Points})
+	case model.OutboxUpdateDetail:
+		var fields bson.M
+		if err := bson.UnmarshalExtJSON(e.Payload, true, &fields); err != nil {
+			return err
+		}
+		return mongoRepo.Update(oid, fields)
+	default:
+		return fmt.Errorf("unknown outbox operation %q", e.Operation)
+	}
+}
+
+// outboxBackoff: 5s, 10s, 20s, ... maksimal 10 menit
+func outboxBackoff(attempts int) time.Duration {
+	d := 5 * time.Second
+	for i := 1; i < attempts && d < 10*time.Minute; i++ {
+		d *= 2
+	}
+	if d > 10*time.Minute {
+		d = 10 * time.Minute
+	}
+	return d
+}
@@ -0,0 +1,250 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"project_uas/app/model"
+	"project_uas/app/repository"
+	"project_uas/storage"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ReconcileService membandingkan achievement_references.mongo_achievement_id dengan
+// koleksi achievements di Mongo dan (opsional) memperbaiki yatim di kedua arah.
+// File lampiran di storage yang tidak dirujuk dokumen mana pun juga ikut diperiksa.
+type ReconcileService struct {
+	Repo       repository.AchievementRepository
+	MongoRepo  repository.AchievementMongoRepository
+	OutboxRepo repository.OutboxRepository
+	Storage    storage.Storage
+}
+
+func NewReconcileService(repo repository.AchievementRepository, mongoRepo repository.AchievementMongoRepository, outboxRepo repository.OutboxRepository, store storage.Storage) *ReconcileService {
+	return &ReconcileService{Repo: repo, MongoRepo: mongoRepo, OutboxRepo: outboxRepo, Storage: store}
+}
+
+// Run scan kedua sisi. Jika repair=true:
+//   - reference tanpa dokumen: dokumen dibuat ulang dengan me-replay semua entry outbox yang sudah
+//     diterapkan (urut seq). Lampiran tidak ada di outbox, jadi file reference tersebut tidak dihapus
+//     sebagai yatim dan dilaporkan untuk diunggah ulang.
+//   - dokumen tanpa reference yang lebih tua dari grace: dihapus (grace melindungi create yang sedang berjalan)
+//   - file storage yang tidak dirujuk lampiran dan lebih tua dari grace: dihapus (melindungi upload yang sedang berjalan)
+func (s *ReconcileService) Run(repair bool, grace time.Duration) (*model.ReconcileReport, error) {
+	links, err := s.Repo.ListMongoLinks()
+	if err != nil {
+		return nil, err
+	}
+	docs, err := s.MongoRepo.ListAll()
+	if err != nil {
+		return nil, err
+	}
+
+	report := &model.ReconcileReport{
+		CheckedReferences: len(links),
+		CheckedDocuments:  len(docs),
+		OrphanReferences:  []model.OrphanReference{},
+		OrphanDocuments:   []model.OrphanDocument{},
+		OrphanFiles:       []model.OrphanFile{},
+		Repair:            repair,
+	}
+
+	existing := make(map[string]bool, len(docs))
+	for _, d := range docs {
+		existing[d.ID.Hex()] = true
+	}
+	referenced := make(map[string]bool, len(links))
+	for _, l := range links {
+		referenced[l.MongoID] = true
+	}
+
+	// file milik reference yang dokumennya hilang tidak boleh dihapus: lampiran tidak bisa dipulihkan
+	// dari outbox, jadi file di storage adalah satu-satunya salinan
+	missing := make(map[string]bool)
+	for _, l := range links {
+		if existing[l.MongoID] {
+			continue
+		}
+		missing[l.RefID] = true
+		orphan := model.OrphanReference{
+			AchievementRefID:   l.RefID,
+			MongoAchievementID: l.MongoID,
+			Status:             l.Status,
+		}
+		if repair {
+			orphan.Repaired, orphan.Note = s.restoreDocument(l)
+		}
+		report.OrphanReferences = append(report.OrphanReferences, orphan)
+	}
+
+	cutoff := time.Now().Add(-grace)
+	removed := make(map[string]bool)
+	for _, d := range docs {
+		if referenced[d.ID.Hex()] {
+			continue
+		}
+		orphan := model.OrphanDocument{
+			MongoAchievementID: d.ID.Hex(),
+			AchievementRefID:   d.AchievementRefID,
+			CreatedAt:          d.CreatedAt,
+		}
+		if repair {
+			orphan.Repaired, orphan.Note = s.removeDocument(d, cutoff)
+			removed[d.ID.Hex()] = orphan.Repaired
+		}
+		report.OrphanDocuments = append(report.OrphanDocuments, orphan)
+	}
+
+	// file lampiran dari dokumen yang baru dihapus ikut dianggap yatim
+	files := make(map[string]bool)
+	for _, d := range docs {
+		if removed[d.ID.Hex()] {
+			continue
+		}
+		for _, a := range d.Attachments {
+			if k := a.StorageKey(); k != "" {
+				files[k] = true
+			}
+			if a.ThumbnailKey != "" {
+				files[a.ThumbnailKey] = true
+			}
+		}
+	}
+	if err := s.checkFiles(report, files, missing, repair, cutoff); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+func (s *ReconcileService) checkFiles(report *model.ReconcileReport, referenced, missingRefs map[string]bool, repair bool, cutoff time.Time) error {
+	if s.Storage == nil {
+		return nil
+	}
+
+	ctx := context.Background()
+	objects, err := s.Storage.List(ctx, "achievements")
+	if err != nil {
+		return err
+	}
+	report.CheckedFiles = len(objects)
+
+	for _, o := range objects {
+		if referenced[o.Key] {
+			continue
+		}
+		orphan := model.OrphanFile{Key: o.Key, Size: o.Size, LastModified: o.LastModified}
+		if missingRefs[storageRefID(o.Key)] {
+			orphan.Note = "document of reference is missing; file kept, attachment must be re-linked"
+		} else if repair {
+			orphan.Repaired, orphan.Note = s.removeFile(ctx, o, cutoff)
+		}
+		report.OrphanFiles = append(report.OrphanFiles, orphan)
+	}
+	return nil
+}
+
+// restoreDocument me-replay entry outbox yang sudah diterapkan secara berurutan. Entry pertama harus
+// create_detail; kalau tidak (payload awal sudah dibersihkan) reference ditolak dan dilaporkan.
+// Entry yang masih pending tidak di-replay: worker outbox menerapkannya sesudah ini sesuai urutan.
+func (s *ReconcileService) restoreDocument(l model.AchievementRefLink) (bool, string) {
+	if _, err := primitive.ObjectIDFromHex(l.MongoID); err != nil {
+		return false, "invalid mongo id"
+	}
+
+	entries, err := s.OutboxRepo.ListApplied(l.RefID)
+	if err != nil {
+		return false, err.Error()
+	}
+	if len(entries) == 0 || entries[0].Operation != model.OutboxCreateDetail {
+		return false, "no create_detail outbox entry to replay from"
+	}
+
+	for _, e := range entries {
+		if err := applyOutboxEntry(s.MongoRepo, e); err != nil {
+			return false, fmt.Sprintf("replay %s (%s): %v", e.ID, e.Operation, err)
+		}
+	}
+	if l.Status == model.StatusDeleted {
+		oid, _ := primitive.ObjectIDFromHex(l.MongoID)
+		if err := s.MongoRepo.MarkDeleted(oid); err != nil {
+			return false, err.Error()
+		}
+	}
+	return true, fmt.Sprintf("restored by replaying %d outbox entries; attachments are not restored", len(entries))
+}
+
+func (s *ReconcileService) removeDocument(d model.AchievementMongo, cutoff time.Time) (bool, string) {
+	if d.CreatedAt.After(cutoff) {
+		return false, "within grace period"
+	}
+
+	if err := s.MongoRepo.Delete(d.ID); err != nil {
+		return false, err.Error()
+	}
+	return true, "deleted"
+}
+
+func (s *ReconcileService) removeFile(ctx context.Context, o storage.ObjectInfo, cutoff time.Time) (bool, string) {
+	if o.LastModified.After(cutoff) {
+		return false, "within grace period"
+	}
+
+	if err := s.Storage.Delete(ctx, o.Key); err != nil {
+		return false, err.Error()
+	}
+	return true, "deleted"
+}
+
+// BackfillAchievementSummaries isi achievement_type/points/tags reference lama dari dokumen Mongo.
+// Reference yang dokumennya belum ada dilewati (diisi saat reconcile / edit berikutnya).
+func BackfillAchievementSummaries(repo repository.AchievementRepository, mongoRepo repository.AchievementMongoRepository) (int, error) {
+	filled := 0
+	after := ""
+	for {
+		links, err := repo.ListMissingSummaries(after, 500)
+		if err != nil || len(links) == 0 {
+			return filled, err
+		}
+		after = links[len(links)-1].RefID
+
+		ids := make([]primitive.ObjectID, 0, len(links))
+		for _, l := range links {
+			if oid, err := primitive.ObjectIDFromHex(l.MongoID); err == nil {
+				ids = append(ids, oid)
+			}
+		}
+		docs, err := mongoRepo.FindSummaries(ids)
+		if err != nil {
+			return filled, err
+		}
+
+		for _, l := range links {
+			oid, err := primitive.ObjectIDFromHex(l.MongoID)
+			if err != nil {
+				continue
+			}
+			d, ok := docs[oid]
+			if !ok {
+				continue
+			}
+			summary := model.AchievementSummary{AchievementType: d.AchievementType, Points: d.Points, Tags: d.Tags}
+			if err := repo.SetSummary(l.RefID, summary); err != nil {
+				return filled, err
+			}
+			filled++
+		}
+	}
+}
+
+// storageRefID ambil refID dari key "achievements/<refID>/<file>".
+func storageRefID(key string) string {
+	parts := strings.SplitN(key, "/", 3)
+	if len(parts) < 3 {
+		return ""
+	}
+	return parts[1]
+}
@@ -0,0 +1,236 @@
+package service
+
+import (
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+
+	"project_uas/app/model"
+	"project_uas/app/repository"
+	"project_uas/utils"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+//
+// ===== TOKEN STORE =====
+// Refresh token dilacak per family di Postgres dan dirotasi tiap refresh (reuse -> family dicabut).
+// Pencabutan access token (denylist jti, family, batas iat per user) disimpan di Postgres dan
+// di-cache di memori supaya JWTMiddleware tidak perlu query; replika lain mendapat pencabutan
+// lewat LISTEN token_revocations. Cache hanya menyimpan pencabutan dalam umur access token.
+//
+
+// TokenStore juga memenuhi middleware.TokenRevocationChecker
+type TokenStore struct {
+	Repo repository.TokenRepository
+
+	mu       sync.RWMutex
+	access   map[string]time.Time // jti -> exp
+	families map[string]time.Time // family id -> waktu dicabut
+	users    map[string]time.Time // user id -> batas iat
+}
+
+func NewTokenStore(repo repository.TokenRepository) *TokenStore {
+	return &TokenStore{
+		Repo:     repo,
+		access:   map[string]time.Time{},
+		families: map[string]time.Time{},
+		users:    map[string]time.Time{},
+	}
+}
+
+// Load isi cache dari tabel (saat start dan setelah koneksi LISTEN tersambung ulang).
+// Digabung, bukan diganti: pencabutan tidak pernah dibatalkan.
+func (s *TokenStore) Load() error {
+	revs, err := s.Repo.ListRevocations(time.Now().Add(-utils.AccessTokenTTL))
+	if err != nil {
+		return err
+	}
+	for _, rev := range revs {
+		s.apply(rev)
+	}
+	return nil
+}
+
+func (s *TokenStore) apply(rev model.TokenRevocation) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch rev.Kind {
+	case model.TokenRevocationAccess:
+		s.access[rev.ID] = rev.At
+	case model.TokenRevocationFamily:
+		s.families[rev.ID] = rev.At
+	case model.TokenRevocationUser:
+		if cur, ok := s.users[rev.ID]; !ok || rev.At.After(cur) {
+			s.users[rev.ID] = rev.At
+		}
+	}
+}
+
+// Run membaca notifikasi LISTEN sampai stop ditutup. Notifikasi nil (koneksi tersambung ulang)
+// -> muat ulang dari tabel. Cache dan token kedaluwarsa dipangkas tiap jam.
+func (s *TokenStore) Run(notify <-chan *pq.Notification, stop <-chan struct{}) {
+	prune := time.NewTicker(time.Hour)
+	defer prune.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case n := <-notify:
+			if n == nil {
+				if err := s.Load(); err != nil {
+					log.Println("token store reload:", err)
+				}
+				continue
+			}
+			var rev model.TokenRevocation
+			if err := json.Unmarshal([]byte(n.Extra), &rev); err != nil {
+				log.Println("token store notify:", err)
+				continue
+			}
+			s.apply(rev)
+		case <-prune.C:
+			s.pruneCache(time.Now())
+			if _, err := s.Repo.Prune(); err != nil {
+				log.Println("token store prune:", err)
+			}
+		}
+	}
+}
+
+func (s *TokenStore) pruneCache(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	oldest := now.Add(-utils.AccessTokenTTL)
+	for jti, exp := range s.access {
+		if exp.Before(now) {
+			delete(s.access, jti)
+		}
+	}
+	for id, at := range s.families {
+		if at.Before(oldest) {
+			delete(s.families, id)
+		}
+	}
+	for id, at := range s.users {
+		if at.Before(oldest) {
+			delete(s.users, id)
+		}
+	}
+}
+
+// AccessTokenRevoked cek access token (signature sudah valid) terhadap cache pencabutan
+func (s *TokenStore) AccessTokenRevoked(claims jwt.MapClaims) bool {
+	jti, _ := claims["jti"].(string)
+	fid, _ := claims["fid"].(string)
+	sub, _ := claims["sub"].(string)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, ok := s.access[jti]; ok && jti != "" {
+		return true
+	}
+	if _, ok := s.families[fid]; ok && fid != "" {
+		return true
+	}
+	if cutoff, ok := s.users[sub]; ok {
+		// iat dibulatkan ke bawah ke detik: iat < batas berarti token mungkin terbit sebelum batas
+		iat, err := claims.GetIssuedAt()
+		return err != nil || iat == nil || iat.Time.Before(cutoff)
+	}
+	return false
+}
+
+// StartSession family baru untuk login, mengembalikan refresh token dan family id
+func (s *TokenStore) StartSession(userID string) (string, string, error) {
+	familyID := uuid.NewString()
+	token, claims, err := utils.GenerateRefreshToken(userID, familyID)
+	if err != nil {
+		return "", "", err
+	}
+	if err := s.Repo.StartFamily(refreshRecord(claims)); err != nil {
+		return "", "", err
+	}
+	return token, familyID, nil
+}
+
+// Rotate tukar refresh token dengan penggantinya dalam family yang sama.
+// Reuse token lama -> family dicabut (juga access token-nya) dan repository.ErrRefreshTokenReused.
+func (s *TokenStore) Rotate(old *utils.RefreshClaims) (string, error) {
+	token, claims, err := utils.GenerateRefreshToken(old.Subject, old.FamilyID)
+	if err != nil {
+		return "", err
+	}
+
+	next := refreshRecord(claims)
+	err = s.Repo.Rotate(old.ID, next)
+	if err == repository.ErrRefreshTokenReused {
+		log.Println("refresh token reuse detected, family revoked:", old.FamilyID, "user:", old.Subject)
+		s.apply(model.TokenRevocation{Kind: model.TokenRevocationFamily, ID: old.FamilyID, At: next.IssuedAt})
+	}
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+func refreshRecord(c *utils.RefreshClaims) model.RefreshTokenRecord {
+	return model.RefreshTokenRecord{
+		JTI:       c.ID,
+		FamilyID:  c.FamilyID,
+		UserID:    c.Subject,
+		IssuedAt:  c.IssuedAt.Time,
+		ExpiresAt: c.ExpiresAt.Time,
+	}
+}
+
+// RevokeSession logout: access token ini masuk denylist dan family-nya (refresh token sesi ini) dicabut
+func (s *TokenStore) RevokeSession(claims jwt.MapClaims) error {
+	userID, _ := claims["sub"].(string)
+
+	if jti, _ := claims["jti"].(string); jti != "" {
+		exp, err := claims.GetExpirationTime()
+		if err != nil || exp == nil {
+			return jwt.ErrTokenInvalidClaims
+		}
+		if err := s.Repo.RevokeAccessToken(jti, userID, exp.Time); err != nil {
+			return err
+		}
+		s.apply(model.TokenRevocation{Kind: model.TokenRevocationAccess, ID: jti, At: exp.Time})
+	}
+
+	if fid, _ := claims["fid"].(string); fid != "" {
+		now := time.Now()
+		revoked, err := s.Repo.RevokeFamily(fid, userID, model.TokenRevokeLogout, now)
+		if err != nil {
+			return err
+		}
+		if revoked {
+			s.apply(model.TokenRevocation{Kind: model.TokenRevocationFamily, ID: fid, At: now})
+		}
+	}
+	return nil
+}
+
+// RevokeUser putus semua sesi user (logout semua perangkat / user dinonaktifkan / reset password).
+// Batas tidak dibulatkan: iat JWT per detik, jadi token yang terbit di detik yang sama (sebelum
+// maupun sesudah batas) ikut ditolak. Dibulatkan ke atas ke mikrodetik (presisi TIMESTAMPTZ)
+// supaya batas yang dimuat ulang dari tabel tidak mundur.
+func (s *TokenStore) RevokeUser(userID, reason string) error {
+	at := time.Now().Truncate(time.Microsecond).Add(time.Microsecond)
+	revoked, err := s.Repo.RevokeUser(userID, reason, at)
+	if err != nil {
+		return err
+	}
+	if revoked {
+		s.apply(model.TokenRevocation{Kind: model.TokenRevocationUser, ID: userID, At: at})
+	}
+	return nil
+}
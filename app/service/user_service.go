@@ -4,6 +4,7 @@ import (
 	"strings"
 
 
+	"project_uas/app/model"
 	"project_uas/app/repository"
 	"project_uas/utils"
 
@@ -11,11 +12,12 @@ import (
 )
 
 type UserService struct {
-	Repo repository.UserRepository
+	Repo   repository.UserRepository
+	Tokens *TokenStore
 }
 
-func NewUserService(repo repository.UserRepository) *UserService {
-	return &UserService{Repo: repo}
+func NewUserService(repo repository.UserRepository, tokens *TokenStore) *UserService {
+	return &UserService{Repo: repo, Tokens: tokens}
 }
 
 // GetUsers godoc
@@ -159,7 +161,7 @@ func (s *UserService) UpdateUser(c *fiber.Ctx) error {
 
 // DeleteUser godoc
 // @Summary Deactivate user
-// @Description Soft delete user (set is_active=false)
+// @Description Soft delete user (set is_active=false). Semua token user langsung dicabut.
 // @Tags Users
 // @Security BearerAuth
 // @Produce json
@@ -175,6 +177,10 @@ func (s *UserService) DeleteUser(c *fiber.Ctx) error {
 	if err := s.Repo.Deactivate(id); err != nil {
 		return c.Status(500).JSON(fiber.Map{"message": "failed to deactivate user"})
 	}
+	// putus sesi yang sedang berjalan; login dan refresh sudah ditolak karena is_active=false
+	if err := s.Tokens.RevokeUser(id, model.TokenRevokeDeactivated); err != nil {
+		return c.Status(500).JSON(fiber.Map{"message": "failed to revoke user tokens"})
+	}
 	return c.JSON(fiber.Map{"message": "user deactivated"})
 }
 
@@ -0,0 +1,542 @@
+package service
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"project_uas/app/model"
+	"project_uas/app/repository"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+//
+// ===== WEBHOOKS =====
+// Event prestasi dikirim ke langganan yang didaftarkan admin. Delivery ditulis ke webhook_deliveries
+// di transaksi transisinya dengan reference yang dibekukan saat itu; detail Mongo ditempel sekali
+// sebelum kirim pertama (setelah outbox event tersebut diterapkan). Dikirim dengan retry
+// exponential. Tiap request ditandatangani HMAC-SHA256 memakai secret langganan:
+//
+//	X-Webhook-Signature: sha256=hex(HMAC(secret, X-Webhook-Timestamp + "." + body))
+//
+
+// header request webhook
+const (
+	WebhookHeaderEvent     = "X-Webhook-Event"
+	WebhookHeaderEventID   = "X-Webhook-Event-Id"
+	WebhookHeaderDelivery  = "X-Webhook-Delivery"
+	WebhookHeaderTimestamp = "X-Webhook-Timestamp"
+	WebhookHeaderSignature = "X-Webhook-Signature"
+)
+
+// batas potongan response penerima yang disimpan di log percobaan
+const webhookResponseLogLimit = 2048
+
+type WebhookService struct {
+	Repo      repository.WebhookRepository
+	MongoRepo repository.AchievementMongoRepository
+	Client    *http.Client
+	// MaxAttempts WEBHOOK_MAX_ATTEMPTS (default 10), setelah itu delivery failed (bisa redeliver manual)
+	MaxAttempts int
+
+	// wake sinyal dari HandleEvent ke Run (buffer 1: sinyal yang menumpuk digabung)
+	wake chan struct{}
+}
+
+func NewWebhookService(repo repository.WebhookRepository, mongoRepo repository.AchievementMongoRepository) *WebhookService {
+	return &WebhookService{
+		Repo:        repo,
+		MongoRepo:   mongoRepo,
+		Client:      &http.Client{Timeout: time.Duration(envInt("WEBHOOK_TIMEOUT_SECONDS", 10)) * time.Second},
+		MaxAttempts: envInt("WEBHOOK_MAX_ATTEMPTS", 10),
+		wake:        make(chan struct{}, 1),
+	}
+}
+
+// HandleEvent dipasang lewat EventBus.Subscribe. Delivery sudah tersimpan di transaksi transisi;
+// event bus hanya membangunkan Run supaya tidak menunggu tick berikutnya. Tidak pernah memblokir
+// worker EventBus.
+func (s *WebhookService) HandleEvent(evt model.AchievementEvent) {
+	if !containsEvent(model.WebhookEvents, evt.Type) {
+		return
+	}
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func containsEvent(list []string, v string) bool {
+	for _, x := range list {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+// attachDetail tempel dokumen Mongo ke payload; dokumen tidak ada -> detail null (tetap dibekukan)
+func (s *WebhookService) attachDetail(d model.WebhookDispatch) ([]byte, error) {
+	var payload model.WebhookPayload
+	if err := json.Unmarshal(d.Payload, &payload); err != nil {
+		return nil, err
+	}
+
+	var doc *model.AchievementMongo
+	if ref := payload.Data.Reference; ref != nil {
+		if oid, err := primitive.ObjectIDFromHex(ref.MongoAchievementID); err == nil {
+			found, err := s.MongoRepo.FindByID(oid)
+			if err != nil && err != mongo.ErrNoDocuments {
+				return nil, err
+			}
+			doc = found
+		}
+	}
+	detail, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	return s.Repo.AttachDetail(d.EventID, d.ID, detail)
+}
+
+// Dispatch langsung mengirim satu delivery (gagal -> dijadwalkan ulang untuk worker).
+func (s *WebhookService) Dispatch(id string) {
+	if _, err := s.process(id, 1); err != nil {
+		log.Println("webhook dispatch:", err)
+	}
+}
+
+func (s *WebhookService) ProcessPending(limit int) (int, error) {
+	return s.process("", limit)
+}
+
+// Run worker periodik sampai stop ditutup; juga langsung jalan saat dibangunkan HandleEvent.
+func (s *WebhookService) Run(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		case <-s.wake:
+		}
+		if _, err := s.ProcessPending(50); err != nil {
+			log.Println("webhook worker:", err)
+		}
+	}
+}
+
+func (s *WebhookService) process(id string, limit int) (int, error) {
+	deliveries, err := s.Repo.ClaimPending(id, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	for _, d := range deliveries {
+		attempt := s.deliver(d)
+		if attempt.Error == nil {
+			if err := s.Repo.MarkDelivered(d.ID, attempt); err != nil {
+				log.Println("webhook mark delivered:", err)
+				continue
+			}
+			delivered++
+			continue
+		}
+
+		var retryAt *time.Time
+		if d.Attempts < s.MaxAttempts {
+			t := time.Now().Add(webhookBackoff(d.Attempts))
+			retryAt = &t
+		}
+		if err := s.Repo.MarkFailed(d.ID, attempt, retryAt); err != nil {
+			log.Println("webhook mark failed:", err)
+		}
+	}
+	return delivered, nil
+}
+
+// deliver tempel detail Mongo (sekali per event) lalu kirim
+func (s *WebhookService) deliver(d model.WebhookDispatch) model.WebhookDeliveryAttempt {
+	if !d.DetailAttached {
+		payload, err := s.attachDetail(d)
+		if err != nil {
+			// Mongo tidak bisa dibaca: dihitung percobaan gagal, dicoba lagi dengan backoff
+			msg := "attach detail: " + err.Error()
+			return model.WebhookDeliveryAttempt{Attempt: d.Attempts, Error: &msg, AttemptedAt: time.Now()}
+		}
+		d.Payload = payload
+	}
+	return s.send(d)
+}
+
+// send satu POST; Error terisi jika gagal jaringan atau status bukan 2xx
+func (s *WebhookService) send(d model.WebhookDispatch) model.WebhookDeliveryAttempt {
+	started := time.Now()
+	attempt := model.WebhookDeliveryAttempt{Attempt: d.Attempts, AttemptedAt: started}
+	fail := func(err error) model.WebhookDeliveryAttempt {
+		msg := err.Error()
+		attempt.Error = &msg
+		attempt.DurationMs = int(time.Since(started) / time.Millisecond)
+		return attempt
+	}
+
+	req, err := http.NewRequest(http.MethodPost, d.URL, bytes.NewReader(d.Payload))
+	if err != nil {
+		return fail(err)
+	}
+	ts := strconv.FormatInt(started.Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "project-uas-webhook/1.0")
+	req.Header.Set(WebhookHeaderEvent, d.EventType)
+	req.Header.Set(WebhookHeaderEventID, d.EventID)
+	req.Header.Set(WebhookHeaderDelivery, d.ID)
+	req.Header.Set(WebhookHeaderTimestamp, ts)
+	req.Header.Set(WebhookHeaderSignature, SignWebhook(d.Secret, ts, d.Payload))
+
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return fail(err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, webhookResponseLogLimit))
+	code := resp.StatusCode
+	attempt.StatusCode = &code
+	if len(body) > 0 {
+		b := strings.ToValidUTF8(string(body), "")
+		attempt.ResponseBody = &b
+	}
+	if code < 200 || code > 299 {
+		return fail(fmt.Errorf("unexpected status %d", code))
+	}
+	attempt.DurationMs = int(time.Since(started) / time.Millisecond)
+	return attempt
+}
+
+// SignWebhook nilai header X-Webhook-Signature; penerima menghitung ulang dan membandingkan
+// dengan hmac.Equal, serta menolak timestamp yang terlalu lama (replay).
+func SignWebhook(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// webhookBackoff: 30s, 1m, 2m, ... maksimal 1 jam (penerima eksternal bisa down lebih lama dari Mongo)
+func webhookBackoff(attempts int) time.Duration {
+	d := 30 * time.Second
+	for i := 1; i < attempts && d < time.Hour; i++ {
+		d *= 2
+	}
+	if d > time.Hour {
+		d = time.Hour
+	}
+	return d
+}
+
+func newWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+//
+// ===== HTTP =====
+//
+
+// ListWebhooks godoc
+// @Summary List webhook subscriptions
+// @Description Secret tidak pernah ditampilkan ulang; hanya dikembalikan saat dibuat / dirotasi.
+// @Tags Webhooks
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} model.WebhookListResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /webhooks [get]
+func (s *WebhookService) ListWebhooks(c *fiber.Ctx) error {
+	subs, err := s.Repo.List()
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"message": "failed to fetch webhooks"})
+	}
+	return c.JSON(fiber.Map{"data": subs})
+}
+
+// GetWebhook godoc
+// @Summary Get webhook subscription
+// @Tags Webhooks
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Webhook ID"
+// @Success 200 {object} model.WebhookDetailResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /webhooks/{id} [get]
+func (s *WebhookService) GetWebhook(c *fiber.Ctx) error {
+	sub, found, err := s.Repo.GetByID(c.Params("id"))
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"message": "failed to fetch webhook"})
+	}
+	if !found {
+		return c.Status(404).JSON(fiber.Map{"message": "webhook not found"})
+	}
+	return c.JSON(fiber.Map{"data": sub})
+}
+
+// CreateWebhook godoc
+// @Summary Create webhook subscription
+// @Description events kosong = semua event (achievement.created, achievement.submitted, achievement.awaiting_approval, achievement.verified, achievement.rejected, achievement.deleted).
+// @Description secret kosong = dibuat otomatis. Secret hanya dikembalikan di response ini.
+// @Tags Webhooks
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param body body model.WebhookSubscriptionRequest true "Request body"
+// @Success 201 {object} model.WebhookDetailResponse
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 422 {object} model.ValidationErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /webhooks [post]
+func (s *WebhookService) CreateWebhook(c *fiber.Ctx) error {
+	_, userID, ok := getRoleAndUserID(c)
+	if !ok {
+		return c.SendStatus(fiber.StatusUnauthorized)
+	}
+
+	var body model.WebhookSubscriptionRequest
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(400).JSON(fiber.Map{"message": "invalid body"})
+	}
+	if errs := body.Validate(); len(errs) > 0 {
+		return respondValidationError(c, errs)
+	}
+
+	sub := webhookFromRequest(body)
+	sub.CreatedBy = &userID
+	if sub.Secret == "" {
+		secret, err := newWebhookSecret()
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{"message": "failed to generate secret"})
+		}
+		sub.Secret = secret
+	}
+
+	if err := s.Repo.Create(&sub); err != nil {
+		return c.Status(500).JSON(fiber.Map{"message": "failed to create webhook"})
+	}
+	return c.Status(201).JSON(fiber.Map{"data": sub})
+}
+
+// UpdateWebhook godoc
+// @Summary Update webhook subscription
+// @Description Mengganti name, url, events, is_active. secret diisi = rotasi (secret baru dikembalikan sekali), kosong = tetap.
+// @Tags Webhooks
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Webhook ID"
+// @Param body body model.WebhookSubscriptionRequest true "Request body"
+// @Success 200 {object} model.WebhookDetailResponse
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 422 {object} model.ValidationErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /webhooks/{id} [put]
+func (s *WebhookService) UpdateWebhook(c *fiber.Ctx) error {
+	var body model.WebhookSubscriptionRequest
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(400).JSON(fiber.Map{"message": "invalid body"})
+	}
+	if errs := body.Validate(); len(errs) > 0 {
+		return respondValidationError(c, errs)
+	}
+
+	sub := webhookFromRequest(body)
+	sub.ID = c.Params("id")
+	found, err := s.Repo.Update(&sub)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"message": "failed to update webhook"})
+	}
+	if !found {
+		return c.Status(404).JSON(fiber.Map{"message": "webhook not found"})
+	}
+	return c.JSON(fiber.Map{"data": sub})
+}
+
+// DeleteWebhook godoc
+// @Summary Deactivate webhook subscription
+// @Description Langganan dinonaktifkan (log pengiriman tetap tersimpan); delivery pending tidak dikirim.
+// @Tags Webhooks
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Webhook ID"
+// @Success 200 {object} model.MessageResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /webhooks/{id} [delete]
+func (s *WebhookService) DeleteWebhook(c *fiber.Ctx) error {
+	found, err := s.Repo.Deactivate(c.Params("id"))
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"message": "failed to deactivate webhook"})
+	}
+	if !found {
+		return c.Status(404).JSON(fiber.Map{"message": "webhook not found"})
+	}
+	return c.JSON(fiber.Map{"message": "webhook deactivated"})
+}
+
+func webhookFromRequest(r model.WebhookSubscriptionRequest) model.WebhookSubscription {
+	sub := model.WebhookSubscription{
+		Name:     r.Name,
+		URL:      r.URL,
+		Secret:   r.Secret,
+		Events:   []string{},
+		IsActive: true,
+	}
+	for _, e := range r.Events {
+		if !containsEvent(sub.Events, e) {
+			sub.Events = append(sub.Events, e)
+		}
+	}
+	if r.IsActive != nil {
+		sub.IsActive = *r.IsActive
+	}
+	return sub
+}
+
+// ListWebhookDeliveries godoc
+// @Summary Webhook delivery log
+// @Description Pengiriman terbaru dulu. status: pending | delivered | failed.
+// @Tags Webhooks
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Webhook ID"
+// @Param status query string false "Filter status"
+// @Param limit query int false "Limit (maks 100)" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} model.WebhookDeliveryListResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /webhooks/{id}/deliveries [get]
+func (s *WebhookService) ListWebhookDeliveries(c *fiber.Ctx) error {
+	subID := c.Params("id")
+	if _, found, err := s.Repo.GetByID(subID); err != nil {
+		return c.Status(500).JSON(fiber.Map{"message": "failed to fetch webhook"})
+	} else if !found {
+		return c.Status(404).JSON(fiber.Map{"message": "webhook not found"})
+	}
+
+	limit := c.QueryInt("limit", 20)
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	offset := c.QueryInt("offset", 0)
+	if offset < 0 {
+		offset = 0
+	}
+
+	items, total, err := s.Repo.ListDeliveries(subID, c.Query("status"), limit, offset)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"message": "failed to fetch deliveries"})
+	}
+	return c.JSON(model.WebhookDeliveryListResponse{
+		Data: items,
+		Meta: model.WebhookDeliveryMeta{Total: total, Limit: limit, Offset: offset},
+	})
+}
+
+// GetWebhookDelivery godoc
+// @Summary Get webhook delivery
+// @Description Termasuk payload yang dikirim dan log tiap percobaan (status HTTP, error, potongan response).
+// @Tags Webhooks
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Webhook ID"
+// @Param deliveryId path string true "Delivery ID"
+// @Success 200 {object} model.WebhookDeliveryDetailResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /webhooks/{id}/deliveries/{deliveryId} [get]
+func (s *WebhookService) GetWebhookDelivery(c *fiber.Ctx) error {
+	d, found, err := s.Repo.GetDelivery(c.Params("id"), c.Params("deliveryId"))
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"message": "failed to fetch delivery"})
+	}
+	if !found {
+		return c.Status(404).JSON(fiber.Map{"message": "delivery not found"})
+	}
+	return c.JSON(fiber.Map{"data": d})
+}
+
+// RedeliverWebhook godoc
+// @Summary Redeliver webhook
+// @Description Membuat delivery baru dengan payload dan event id yang sama lalu langsung mengirimnya.
+// @Description Hasil pengiriman dilihat di log delivery baru.
+// @Tags Webhooks
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Webhook ID"
+// @Param deliveryId path string true "Delivery ID"
+// @Success 202 {object} model.WebhookRedeliverResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 409 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /webhooks/{id}/deliveries/{deliveryId}/redeliver [post]
+func (s *WebhookService) RedeliverWebhook(c *fiber.Ctx) error {
+	subID := c.Params("id")
+	sub, found, err := s.Repo.GetByID(subID)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"message": "failed to fetch webhook"})
+	}
+	if !found {
+		return c.Status(404).JSON(fiber.Map{"message": "webhook not found"})
+	}
+	if !sub.IsActive {
+		return c.Status(409).JSON(fiber.Map{"message": "webhook is inactive"})
+	}
+
+	newID, found, err := s.Repo.Redeliver(subID, c.Params("deliveryId"))
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"message": "failed to redeliver"})
+	}
+	if !found {
+		return c.Status(404).JSON(fiber.Map{"message": "delivery not found"})
+	}
+	go s.Dispatch(newID)
+
+	return c.Status(202).JSON(fiber.Map{"data": fiber.Map{"delivery_id": newID}})
+}
@@ -0,0 +1,66 @@
+// Command reconcile membandingkan achievement_references (Postgres) dengan koleksi
+// achievements (MongoDB) dan melaporkan / memperbaiki data yatim di kedua arah,
+// termasuk file lampiran di storage yang tidak lagi dirujuk.
+//
+//	go run ./cmd/reconcile            # laporan saja
+//	go run ./cmd/reconcile -repair    # laporan + perbaikan
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+	"time"
+
+	"project_uas/app/repository"
+	"project_uas/app/service"
+	"project_uas/database"
+	"project_uas/storage"
+
+	"github.com/joho/godotenv"
+)
+
+func main() {
+	repair := flag.Bool("repair", false, "perbaiki data yatim (restore dokumen dari outbox, hapus dokumen tanpa reference dan file tanpa lampiran)")
+	grace := flag.Duration("grace", 15*time.Minute, "dokumen Mongo / file yang lebih muda dari ini tidak dihapus")
+	flag.Parse()
+
+	_ = godotenv.Load()
+
+	database.ConnectPostgres()
+	database.Migrate(database.DB)
+	database.ConnectMongo()
+
+	// selesaikan outbox yang tertunda dulu supaya tidak terhitung yatim
+	outboxRepo := repository.NewOutboxRepository(database.DB)
+	mongoRepo := repository.NewAchievementMongoRepository(database.MongoDB)
+	if _, err := service.NewOutboxProcessor(outboxRepo, mongoRepo).ProcessPending(1000); err != nil {
+		log.Println("outbox:", err)
+	}
+
+	store, err := storage.NewFromEnv()
+	if err != nil {
+		log.Fatal("storage init error:", err)
+	}
+
+	svc := service.NewReconcileService(
+		repository.NewAchievementRepository(database.DB),
+		mongoRepo,
+		outboxRepo,
+		store,
+	)
+
+	report, err := svc.Run(*repair, *grace)
+	if err != nil {
+		log.Fatal("reconcile failed:", err)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(report)
+
+	if !*repair && (len(report.OrphanReferences) > 0 || len(report.OrphanDocuments) > 0 || len(report.OrphanFiles) > 0) {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,71 @@
+package database
+
+import (
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"log"
+	"sort"
+	"strings"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// Migrate menjalankan file SQL di database/migrations yang belum tercatat
+// di tabel schema_migrations, urut berdasarkan nama file.
+func Migrate(db *sql.DB) {
+	if _, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version    VARCHAR(255) PRIMARY KEY,
+			applied_at TIMESTAMP NOT NULL DEFAULT NOW()
+		)
+	`); err != nil {
+		log.Fatal("failed prepare schema_migrations:", err)
+	}
+
+	names, err := fs.Glob(migrationFiles, "migrations/*.sql")
+	if err != nil {
+		log.Fatal("failed read migrations:", err)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		version := strings.TrimSuffix(strings.TrimPrefix(name, "migrations/"), ".sql")
+
+		var exists bool
+		if err := db.QueryRow(`SELECT EXISTS(SELECT 1 FROM schema_migrations WHERE version=$1)`, version).Scan(&exists); err != nil {
+			log.Fatal("failed check migration:", err)
+		}
+		if exists {
+			continue
+		}
+
+		if err := applyMigration(db, name, version); err != nil {
+			log.Fatal("failed apply migration "+version+":", err)
+		}
+		fmt.Println("migration applied:", version)
+	}
+}
+
+func applyMigration(db *sql.DB, name, version string) error {
+	body, err := migrationFiles.ReadFile(name)
+	if err != nil {
+		return err
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(string(body)); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`INSERT INTO schema_migrations (version) VALUES ($1)`, version); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,42 @@
+-- Riwayat perubahan status prestasi (event log, append-only)
+CREATE TABLE IF NOT EXISTS achievement_status_history (
+    id                 UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    achievement_ref_id UUID NOT NULL REFERENCES achievement_references(id),
+    action             VARCHAR(50) NOT NULL,
+    from_status        VARCHAR(50),
+    to_status          VARCHAR(50) NOT NULL,
+    actor_user_id      UUID REFERENCES users(id),
+    note               TEXT,
+    created_at         TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_achievement_status_history_ref
+    ON achievement_status_history (achievement_ref_id, created_at);
+
+-- Backfill data lama dari kolom timestamp yang ada (actor tidak diketahui kecuali verified_by)
+INSERT INTO achievement_status_history (achievement_ref_id, action, from_status, to_status, actor_user_id, created_at)
+SELECT ar.id, 'create', NULL, 'draft', s.user_id, ar.created_at
+FROM achievement_references ar
+JOIN students s ON s.id = ar.student_id;
+
+INSERT INTO achievement_status_history (achievement_ref_id, action, from_status, to_status, actor_user_id, created_at)
+SELECT ar.id, 'submit', 'draft', 'submitted', s.user_id, ar.submitted_at
+FROM achievement_references ar
+JOIN students s ON s.id = ar.student_id
+WHERE ar.submitted_at IS NOT NULL;
+
+INSERT INTO achievement_status_history (achievement_ref_id, action, from_status, to_status, actor_user_id, created_at)
+SELECT ar.id, 'verify', 'submitted', 'verified', ar.verified_by, ar.verified_at
+FROM achievement_references ar
+WHERE ar.status = 'verified' AND ar.verified_at IS NOT NULL;
+
+INSERT INTO achievement_status_history (achievement_ref_id, action, from_status, to_status, note, created_at)
+SELECT ar.id, 'reject', 'submitted', 'rejected', ar.rejection_note, ar.updated_at
+FROM achievement_references ar
+WHERE ar.status = 'rejected';
+
+INSERT INTO achievement_status_history (achievement_ref_id, action, from_status, to_status, actor_user_id, created_at)
+SELECT ar.id, 'delete', 'draft', 'deleted', s.user_id, ar.updated_at
+FROM achievement_references ar
+JOIN students s ON s.id = ar.student_id
+WHERE ar.status = 'deleted';
@@ -0,0 +1,10 @@
+-- Alur revisi: rejected -> revision -> submitted
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM pg_type WHERE typname = 'achievement_status') THEN
+        ALTER TYPE achievement_status ADD VALUE IF NOT EXISTS 'revision';
+    END IF;
+END $$;
+
+ALTER TABLE achievement_references
+    ADD COLUMN IF NOT EXISTS revision_count INT NOT NULL DEFAULT 0;
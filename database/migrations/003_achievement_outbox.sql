@@ -0,0 +1,21 @@
+-- Outbox: perubahan Mongo dicatat di transaksi Postgres yang sama, lalu diterapkan oleh worker
+CREATE TABLE IF NOT EXISTS achievement_outbox (
+    id                   UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    achievement_ref_id   UUID NOT NULL,
+    mongo_achievement_id VARCHAR(24) NOT NULL,
+    operation            VARCHAR(50) NOT NULL,
+    payload              JSONB,
+    status               VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts             INT NOT NULL DEFAULT 0,
+    last_error           TEXT,
+    next_attempt_at      TIMESTAMP NOT NULL DEFAULT NOW(),
+    locked_until         TIMESTAMP,
+    created_at           TIMESTAMP NOT NULL DEFAULT NOW(),
+    processed_at         TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_achievement_outbox_pending
+    ON achievement_outbox (status, next_attempt_at);
+
+CREATE INDEX IF NOT EXISTS idx_achievement_outbox_ref
+    ON achievement_outbox (achievement_ref_id, created_at);
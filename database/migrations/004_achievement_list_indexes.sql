@@ -0,0 +1,9 @@
+-- Index untuk keyset pagination & filter GET /achievements
+CREATE INDEX IF NOT EXISTS idx_achievement_references_created
+    ON achievement_references (created_at DESC, id DESC);
+
+CREATE INDEX IF NOT EXISTS idx_achievement_references_updated
+    ON achievement_references (updated_at DESC, id DESC);
+
+CREATE INDEX IF NOT EXISTS idx_achievement_references_student_status
+    ON achievement_references (student_id, status);
@@ -0,0 +1,64 @@
+-- Katalog tipe prestasi (dikelola admin) + aturan poin per tipe/level/rank
+CREATE TABLE IF NOT EXISTS achievement_types (
+    code        VARCHAR(50) PRIMARY KEY,
+    name        VARCHAR(100) NOT NULL,
+    schema      VARCHAR(50) NOT NULL, -- skema details (competition, publication, ...)
+    description TEXT,
+    levels      TEXT[] NOT NULL DEFAULT '{}',
+    is_active   BOOLEAN NOT NULL DEFAULT true,
+    created_at  TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- level '' = semua level, rank 0 = semua rank; aturan paling spesifik yang dipakai
+CREATE TABLE IF NOT EXISTS achievement_point_rules (
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    type_code  VARCHAR(50) NOT NULL REFERENCES achievement_types(code) ON DELETE CASCADE,
+    level      VARCHAR(50) NOT NULL DEFAULT '',
+    rank       INT NOT NULL DEFAULT 0 CHECK (rank >= 0),
+    points     INT NOT NULL CHECK (points >= 0),
+    UNIQUE (type_code, level, rank)
+);
+
+INSERT INTO achievement_types (code, name, schema, levels) VALUES
+    ('competition',   'Kompetisi',   'competition',   '{international,national,regional,local}'),
+    ('publication',   'Publikasi',   'publication',   '{international,national}'),
+    ('organization',  'Organisasi',  'organization',  '{international,national,regional,local}'),
+    ('certification', 'Sertifikasi', 'certification', '{}'),
+    ('academic',      'Akademik',    'academic',      '{international,national,regional,local}'),
+    ('other',         'Lainnya',     'other',         '{}')
+ON CONFLICT (code) DO NOTHING;
+
+INSERT INTO achievement_point_rules (type_code, level, rank, points) VALUES
+    ('competition',   '',              0, 10),
+    ('competition',   'international', 0, 80),
+    ('competition',   'international', 1, 100),
+    ('competition',   'national',      0, 50),
+    ('competition',   'national',      1, 70),
+    ('competition',   'regional',      0, 30),
+    ('competition',   'local',         0, 15),
+    ('publication',   '',              0, 40),
+    ('publication',   'international', 0, 60),
+    ('organization',  '',              0, 20),
+    ('certification', '',              0, 25),
+    ('academic',      '',              0, 30),
+    ('other',         '',              0, 10)
+ON CONFLICT (type_code, level, rank) DO NOTHING;
+
+-- poin dibekukan saat verify
+ALTER TABLE achievement_references
+    ADD COLUMN IF NOT EXISTS verified_points INT;
+
+INSERT INTO permissions (id, name, resource, action, description)
+SELECT gen_random_uuid(), 'achievement_type:manage', 'achievement_type', 'manage', 'Kelola katalog tipe prestasi dan aturan poin'
+WHERE NOT EXISTS (SELECT 1 FROM permissions WHERE name = 'achievement_type:manage');
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r, permissions p
+WHERE r.name = 'Admin'
+  AND p.name = 'achievement_type:manage'
+  AND NOT EXISTS (
+      SELECT 1 FROM role_permissions rp
+      WHERE rp.role_id = r.id AND rp.permission_id = p.id
+  );
@@ -0,0 +1,46 @@
+-- Override verifikasi oleh admin + delegasi review dosen wali
+ALTER TABLE achievement_status_history
+    ADD COLUMN IF NOT EXISTS justification        TEXT,
+    ADD COLUMN IF NOT EXISTS on_behalf_of_user_id UUID REFERENCES users(id);
+
+-- Delegasi: review pending milik mahasiswa bimbingan lecturer_id boleh dikerjakan
+-- substitute_lecturer_id selama starts_on..ends_on (inklusif)
+CREATE TABLE IF NOT EXISTS review_delegations (
+    id                     UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    lecturer_id            UUID NOT NULL REFERENCES lecturers(id),
+    substitute_lecturer_id UUID NOT NULL REFERENCES lecturers(id),
+    starts_on              DATE NOT NULL,
+    ends_on                DATE NOT NULL,
+    reason                 TEXT,
+    created_by             UUID REFERENCES users(id),
+    created_at             TIMESTAMP NOT NULL DEFAULT NOW(),
+    revoked_at             TIMESTAMP,
+    CHECK (ends_on >= starts_on),
+    CHECK (lecturer_id <> substitute_lecturer_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_review_delegations_lecturer
+    ON review_delegations (lecturer_id, starts_on, ends_on)
+    WHERE revoked_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_review_delegations_substitute
+    ON review_delegations (substitute_lecturer_id, starts_on, ends_on)
+    WHERE revoked_at IS NULL;
+
+INSERT INTO permissions (id, name, resource, action, description)
+SELECT gen_random_uuid(), 'achievement:override', 'achievement', 'override', 'Verifikasi / tolak prestasi atas nama dosen wali'
+WHERE NOT EXISTS (SELECT 1 FROM permissions WHERE name = 'achievement:override');
+
+INSERT INTO permissions (id, name, resource, action, description)
+SELECT gen_random_uuid(), 'review_delegation:manage', 'review_delegation', 'manage', 'Kelola delegasi review dosen wali'
+WHERE NOT EXISTS (SELECT 1 FROM permissions WHERE name = 'review_delegation:manage');
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r, permissions p
+WHERE r.name = 'Admin'
+  AND p.name IN ('achievement:override', 'review_delegation:manage')
+  AND NOT EXISTS (
+      SELECT 1 FROM role_permissions rp
+      WHERE rp.role_id = r.id AND rp.permission_id = p.id
+  );
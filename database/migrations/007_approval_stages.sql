@@ -0,0 +1,74 @@
+-- Rantai persetujuan bertingkat: setelah dosen wali, prestasi yang cocok dengan aturan
+-- approval_stages harus disetujui tiap tahap (urut position) sebelum berstatus verified.
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM pg_type WHERE typname = 'achievement_status') THEN
+        ALTER TYPE achievement_status ADD VALUE IF NOT EXISTS 'awaiting_approval';
+    END IF;
+END $$;
+
+-- type_codes / levels kosong = berlaku untuk semua
+CREATE TABLE IF NOT EXISTS approval_stages (
+    code        VARCHAR(50) PRIMARY KEY,
+    name        VARCHAR(100) NOT NULL,
+    permission  VARCHAR(100) NOT NULL,
+    position    INT NOT NULL CHECK (position > 0),
+    type_codes  TEXT[] NOT NULL DEFAULT '{}',
+    levels      TEXT[] NOT NULL DEFAULT '{}',
+    is_active   BOOLEAN NOT NULL DEFAULT true,
+    created_at  TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Salinan tahap per prestasi (dibuat saat dosen wali verify), per putaran revisi.
+-- Perubahan aturan tidak mempengaruhi prestasi yang sedang berjalan.
+CREATE TABLE IF NOT EXISTS achievement_approvals (
+    id                 UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    achievement_ref_id UUID NOT NULL REFERENCES achievement_references(id),
+    round              INT NOT NULL,
+    position           INT NOT NULL,
+    stage_code         VARCHAR(50) NOT NULL,
+    stage_name         VARCHAR(100) NOT NULL,
+    permission         VARCHAR(100) NOT NULL,
+    status             VARCHAR(20) NOT NULL DEFAULT 'pending', -- pending | approved | rejected | cancelled
+    decided_by         UUID REFERENCES users(id),
+    decided_at         TIMESTAMP,
+    note               TEXT,
+    created_at         TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (achievement_ref_id, round, position)
+);
+
+CREATE INDEX IF NOT EXISTS idx_achievement_approvals_pending
+    ON achievement_approvals (permission, created_at)
+    WHERE status = 'pending';
+
+ALTER TABLE achievement_status_history
+    ADD COLUMN IF NOT EXISTS stage VARCHAR(50);
+
+INSERT INTO approval_stages (code, name, permission, position, levels) VALUES
+    ('faculty', 'Persetujuan Fakultas', 'achievement:approve_faculty', 1, '{national,international}')
+ON CONFLICT (code) DO NOTHING;
+
+INSERT INTO roles (id, name, description)
+SELECT gen_random_uuid(), 'Reviewer Fakultas', 'Reviewer prestasi tingkat fakultas'
+WHERE NOT EXISTS (SELECT 1 FROM roles WHERE name = 'Reviewer Fakultas');
+
+INSERT INTO permissions (id, name, resource, action, description)
+SELECT gen_random_uuid(), 'achievement:approve_faculty', 'achievement', 'approve_faculty', 'Persetujuan prestasi tingkat fakultas'
+WHERE NOT EXISTS (SELECT 1 FROM permissions WHERE name = 'achievement:approve_faculty');
+
+INSERT INTO permissions (id, name, resource, action, description)
+SELECT gen_random_uuid(), 'approval_stage:manage', 'approval_stage', 'manage', 'Kelola aturan tahap persetujuan'
+WHERE NOT EXISTS (SELECT 1 FROM permissions WHERE name = 'approval_stage:manage');
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r, permissions p
+WHERE (
+        (r.name = 'Reviewer Fakultas' AND p.name IN ('achievement:read', 'achievement:approve_faculty'))
+     OR (r.name = 'Admin' AND p.name IN ('achievement:approve_faculty', 'approval_stage:manage'))
+  )
+  AND NOT EXISTS (
+      SELECT 1 FROM role_permissions rp
+      WHERE rp.role_id = r.id AND rp.permission_id = p.id
+  );
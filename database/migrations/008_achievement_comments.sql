@@ -0,0 +1,13 @@
+-- Diskusi per prestasi antara mahasiswa, dosen wali, dan admin (tanpa harus reject)
+CREATE TABLE IF NOT EXISTS achievement_comments (
+    id                 UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    achievement_ref_id UUID NOT NULL REFERENCES achievement_references(id),
+    author_user_id     UUID NOT NULL REFERENCES users(id),
+    body               TEXT NOT NULL,
+    -- id lampiran di dokumen Mongo (opsional), komentar tetap ada walau lampirannya dihapus
+    attachment_id      VARCHAR(255),
+    created_at         TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_achievement_comments_ref
+    ON achievement_comments (achievement_ref_id, created_at);
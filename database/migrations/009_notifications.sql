@@ -0,0 +1,19 @@
+-- Notifikasi in-app per user untuk event alur prestasi (submit, verify, reject, komentar)
+CREATE TABLE IF NOT EXISTS notifications (
+    id                 UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id            UUID NOT NULL REFERENCES users(id),
+    type               VARCHAR(50) NOT NULL,
+    title              VARCHAR(200) NOT NULL,
+    message            TEXT NOT NULL,
+    achievement_ref_id UUID REFERENCES achievement_references(id),
+    actor_user_id      UUID REFERENCES users(id),
+    read_at            TIMESTAMP,
+    created_at         TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_user
+    ON notifications (user_id, created_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_user_unread
+    ON notifications (user_id)
+    WHERE read_at IS NULL;
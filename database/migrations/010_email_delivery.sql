@@ -0,0 +1,49 @@
+-- Preferensi notifikasi per user: opt-out email (semua / per tipe event) dan bahasa email
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    user_id       UUID PRIMARY KEY REFERENCES users(id),
+    email_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    email_opt_out TEXT[] NOT NULL DEFAULT '{}',
+    language      VARCHAR(5) NOT NULL DEFAULT 'id',
+    updated_at    TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Antrian email: sudah dirender, dikirim worker dengan retry + backoff
+CREATE TABLE IF NOT EXISTS email_outbox (
+    id              UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id         UUID REFERENCES users(id),
+    notification_id UUID REFERENCES notifications(id),
+    to_email        VARCHAR(255) NOT NULL,
+    to_name         VARCHAR(255) NOT NULL DEFAULT '',
+    template        VARCHAR(50) NOT NULL,
+    language        VARCHAR(5) NOT NULL,
+    subject         VARCHAR(255) NOT NULL,
+    text_body       TEXT NOT NULL,
+    html_body       TEXT NOT NULL,
+    status          VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts        INT NOT NULL DEFAULT 0,
+    last_error      TEXT,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    locked_until    TIMESTAMP,
+    created_at      TIMESTAMP NOT NULL DEFAULT NOW(),
+    sent_at         TIMESTAMP,
+    CONSTRAINT chk_email_outbox_status CHECK (status IN ('pending', 'sent', 'dead'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_email_outbox_pending
+    ON email_outbox (status, next_attempt_at);
+
+-- Email yang gagal setelah EMAIL_MAX_ATTEMPTS percobaan
+CREATE TABLE IF NOT EXISTS email_dead_letters (
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    email_id   UUID NOT NULL REFERENCES email_outbox(id),
+    user_id    UUID REFERENCES users(id),
+    to_email   VARCHAR(255) NOT NULL,
+    template   VARCHAR(50) NOT NULL,
+    subject    VARCHAR(255) NOT NULL,
+    attempts   INT NOT NULL,
+    last_error TEXT NOT NULL,
+    failed_at  TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_email_dead_letters_failed
+    ON email_dead_letters (failed_at DESC);
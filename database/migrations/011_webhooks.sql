@@ -0,0 +1,68 @@
+-- Webhook keluar: langganan yang didaftarkan admin (URL, secret HMAC, filter event)
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name       VARCHAR(100) NOT NULL,
+    url        TEXT NOT NULL,
+    secret     VARCHAR(255) NOT NULL,
+    -- kosong = semua event
+    events     TEXT[] NOT NULL DEFAULT '{}',
+    is_active  BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by UUID REFERENCES users(id),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Antrian pengiriman: satu baris per (event, langganan); payload dibekukan saat event terjadi
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id                 UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    subscription_id    UUID NOT NULL REFERENCES webhook_subscriptions(id),
+    event_id           UUID NOT NULL,
+    event_type         VARCHAR(50) NOT NULL,
+    achievement_ref_id UUID REFERENCES achievement_references(id),
+    payload            JSONB NOT NULL,
+    status             VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts           INT NOT NULL DEFAULT 0,
+    last_status_code   INT,
+    last_error         TEXT,
+    next_attempt_at    TIMESTAMP NOT NULL DEFAULT NOW(),
+    locked_until       TIMESTAMP,
+    redelivery_of      UUID REFERENCES webhook_deliveries(id),
+    created_at         TIMESTAMP NOT NULL DEFAULT NOW(),
+    delivered_at       TIMESTAMP,
+    CONSTRAINT chk_webhook_deliveries_status CHECK (status IN ('pending', 'delivered', 'failed'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_pending
+    ON webhook_deliveries (status, next_attempt_at);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_subscription
+    ON webhook_deliveries (subscription_id, created_at DESC);
+
+-- Log tiap percobaan kirim (status HTTP, error, potongan response)
+CREATE TABLE IF NOT EXISTS webhook_delivery_attempts (
+    id            UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    delivery_id   UUID NOT NULL REFERENCES webhook_deliveries(id),
+    attempt       INT NOT NULL,
+    status_code   INT,
+    error         TEXT,
+    response_body TEXT,
+    duration_ms   INT NOT NULL,
+    attempted_at  TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_delivery_attempts_delivery
+    ON webhook_delivery_attempts (delivery_id, attempt);
+
+INSERT INTO permissions (id, name, resource, action, description)
+SELECT gen_random_uuid(), 'webhook:manage', 'webhook', 'manage', 'Kelola webhook integrasi'
+WHERE NOT EXISTS (SELECT 1 FROM permissions WHERE name = 'webhook:manage');
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r, permissions p
+WHERE r.name = 'Admin'
+  AND p.name = 'webhook:manage'
+  AND NOT EXISTS (
+      SELECT 1 FROM role_permissions rp
+      WHERE rp.role_id = r.id AND rp.permission_id = p.id
+  );
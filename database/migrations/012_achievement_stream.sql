@@ -0,0 +1,19 @@
+-- Event stream untuk SSE (/api/v1/events): perubahan status dan komentar baru.
+-- Ditulis di transaksi yang sama dengan perubahannya lalu pg_notify('achievement_stream', id);
+-- tiap replika API LISTEN lalu meneruskan ke client SSE miliknya. id dipakai sebagai Last-Event-ID.
+CREATE TABLE IF NOT EXISTS achievement_stream_events (
+    id                 BIGSERIAL PRIMARY KEY,
+    type               VARCHAR(20) NOT NULL,
+    achievement_ref_id UUID NOT NULL REFERENCES achievement_references(id),
+    student_id         UUID NOT NULL,
+    action             VARCHAR(50),
+    from_status        VARCHAR(50),
+    to_status          VARCHAR(50),
+    actor_user_id      UUID REFERENCES users(id),
+    comment_id         UUID REFERENCES achievement_comments(id),
+    created_at         TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_achievement_stream_events_type CHECK (type IN ('status', 'comment'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_achievement_stream_events_created
+    ON achievement_stream_events (created_at);
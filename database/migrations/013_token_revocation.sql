@@ -0,0 +1,47 @@
+-- Revocation token JWT (logout sungguhan).
+-- Refresh token dilacak per jti dalam family (satu family = satu sesi login); tiap refresh merotasi
+-- token. Refresh token yang sudah dirotasi dipakai lagi = indikasi bocor -> seluruh family dicabut.
+-- Access token yang dicabut sebelum kedaluwarsa masuk denylist; users.tokens_revoked_at memutus
+-- semua token yang terbit sebelumnya (logout semua perangkat / user dinonaktifkan).
+-- TIMESTAMPTZ karena dibandingkan dengan iat/exp JWT (waktu aplikasi).
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS tokens_revoked_at TIMESTAMPTZ;
+
+CREATE TABLE IF NOT EXISTS refresh_token_families (
+    id            UUID PRIMARY KEY,
+    user_id       UUID NOT NULL REFERENCES users(id),
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    revoked_at    TIMESTAMPTZ,
+    revoke_reason VARCHAR(20),
+    CONSTRAINT chk_refresh_token_families_reason
+        CHECK (revoke_reason IS NULL OR revoke_reason IN ('logout', 'logout_all', 'reuse', 'deactivated'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_refresh_token_families_user_active
+    ON refresh_token_families (user_id) WHERE revoked_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    jti        UUID PRIMARY KEY,
+    family_id  UUID NOT NULL REFERENCES refresh_token_families(id) ON DELETE CASCADE,
+    parent_jti UUID,
+    issued_at  TIMESTAMPTZ NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    -- terisi saat dirotasi; dipakai lagi setelah ini = reuse
+    used_at    TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_family
+    ON refresh_tokens (family_id);
+
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_expires
+    ON refresh_tokens (expires_at);
+
+CREATE TABLE IF NOT EXISTS revoked_access_tokens (
+    jti        UUID PRIMARY KEY,
+    user_id    UUID NOT NULL REFERENCES users(id),
+    expires_at TIMESTAMPTZ NOT NULL,
+    revoked_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_revoked_access_tokens_expires
+    ON revoked_access_tokens (expires_at);
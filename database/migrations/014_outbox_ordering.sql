@@ -0,0 +1,7 @@
+-- Urutan outbox per reference: entry diterapkan berurutan menurut seq (created_at sama untuk entry
+-- dalam satu transaksi, id UUID acak). Entry berikutnya ditahan selama entry sebelumnya masih pending.
+ALTER TABLE achievement_outbox
+    ADD COLUMN IF NOT EXISTS seq BIGSERIAL;
+
+CREATE INDEX IF NOT EXISTS idx_achievement_outbox_ref_pending
+    ON achievement_outbox (achievement_ref_id, seq) WHERE status = 'pending';
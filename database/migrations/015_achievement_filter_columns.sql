@@ -0,0 +1,16 @@
+-- Salinan field Mongo yang bisa difilter di GET /achievements (tipe, poin, tag) supaya filter dan
+-- cursor cukup satu query SQL. Diisi di transaksi yang sama dengan entry outbox yang mengubahnya;
+-- baris lama (achievement_type NULL) diisi dari Mongo saat start (BackfillAchievementSummaries).
+ALTER TABLE achievement_references
+    ADD COLUMN IF NOT EXISTS achievement_type VARCHAR(50),
+    ADD COLUMN IF NOT EXISTS points           INT,
+    ADD COLUMN IF NOT EXISTS tags             TEXT[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS idx_achievement_references_type
+    ON achievement_references (achievement_type);
+
+CREATE INDEX IF NOT EXISTS idx_achievement_references_points
+    ON achievement_references (points);
+
+CREATE INDEX IF NOT EXISTS idx_achievement_references_tags
+    ON achievement_references USING GIN (tags);
@@ -0,0 +1,21 @@
+-- Reset password lewat email: token acak dikirim ke user, yang disimpan hanya hash SHA-256-nya.
+-- Token sekali pakai; permintaan baru membatalkan token lama yang belum dipakai.
+CREATE TABLE IF NOT EXISTS password_reset_tokens (
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id    UUID NOT NULL REFERENCES users(id),
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at    TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_password_reset_tokens_user_open
+    ON password_reset_tokens (user_id) WHERE used_at IS NULL;
+
+-- password diganti -> semua sesi user dicabut
+ALTER TABLE refresh_token_families
+    DROP CONSTRAINT IF EXISTS chk_refresh_token_families_reason;
+
+ALTER TABLE refresh_token_families
+    ADD CONSTRAINT chk_refresh_token_families_reason
+        CHECK (revoke_reason IS NULL OR revoke_reason IN ('logout', 'logout_all', 'reuse', 'deactivated', 'password_reset'));
@@ -0,0 +1,36 @@
+-- Cursor SSE (Last-Event-ID) urut commit, bukan urut insert. id BIGSERIAL dibagikan saat INSERT,
+-- jadi transaksi yang lebih lama commit bisa muncul dengan id lebih kecil dari id yang sudah
+-- dikirim ke client dan terlewat saat resume. seq dibagikan trigger deferred (jalan tepat sebelum
+-- commit) di bawah advisory lock yang baru dilepas setelah commit: seq terlihat -> semua seq
+-- yang lebih kecil sudah terlihat. NOTIFY juga pindah ke trigger, payload = seq.
+ALTER TABLE achievement_stream_events
+    ADD COLUMN IF NOT EXISTS seq BIGINT;
+
+UPDATE achievement_stream_events SET seq = id WHERE seq IS NULL;
+
+CREATE SEQUENCE IF NOT EXISTS achievement_stream_events_seq;
+
+SELECT setval('achievement_stream_events_seq', GREATEST(COALESCE(MAX(seq), 0), 1), MAX(seq) IS NOT NULL)
+FROM achievement_stream_events;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_achievement_stream_events_seq
+    ON achievement_stream_events (seq);
+
+CREATE OR REPLACE FUNCTION achievement_stream_publish() RETURNS trigger AS $$
+DECLARE
+    next_seq BIGINT;
+BEGIN
+    PERFORM pg_advisory_xact_lock(hashtext('achievement_stream_events_seq'));
+    next_seq := nextval('achievement_stream_events_seq');
+    UPDATE achievement_stream_events SET seq = next_seq WHERE id = NEW.id;
+    PERFORM pg_notify('achievement_stream', next_seq::text);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_achievement_stream_publish ON achievement_stream_events;
+
+CREATE CONSTRAINT TRIGGER trg_achievement_stream_publish
+    AFTER INSERT ON achievement_stream_events
+    DEFERRABLE INITIALLY DEFERRED
+    FOR EACH ROW EXECUTE FUNCTION achievement_stream_publish();
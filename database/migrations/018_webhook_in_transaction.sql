@@ -0,0 +1,8 @@
+-- Delivery webhook ditulis di transaksi transisi (insertHistory) dengan snapshot reference, bukan
+-- lewat EventBus di memori. Detail Mongo belum ada di Postgres saat itu: worker menempelkannya
+-- sekali sebelum kirim pertama, setelah entry outbox event tersebut diterapkan, lalu ikut beku.
+ALTER TABLE webhook_deliveries
+    ADD COLUMN IF NOT EXISTS detail_attached BOOLEAN NOT NULL DEFAULT TRUE;
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_event
+    ON webhook_deliveries (event_id);
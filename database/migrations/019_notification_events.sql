@@ -0,0 +1,32 @@
+-- Event notifikasi ditulis di transaksi yang sama dengan transisi / komentar, lalu diubah jadi
+-- notifikasi oleh worker NotificationService. EventBus hanya membangunkan worker, jadi event tidak
+-- hilang saat buffer penuh atau server restart.
+CREATE TABLE IF NOT EXISTS notification_events (
+    id                 UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    event_type         VARCHAR(50) NOT NULL,
+    achievement_ref_id UUID NOT NULL REFERENCES achievement_references(id),
+    actor_user_id      UUID REFERENCES users(id),
+    achievement_status VARCHAR(30),
+    note               TEXT,
+    comment_id         UUID,
+    status             VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts           INT NOT NULL DEFAULT 0,
+    last_error         TEXT,
+    next_attempt_at    TIMESTAMP NOT NULL DEFAULT NOW(),
+    locked_until       TIMESTAMP,
+    created_at         TIMESTAMP NOT NULL DEFAULT NOW(),
+    processed_at       TIMESTAMP,
+    CONSTRAINT chk_notification_events_status CHECK (status IN ('pending', 'done', 'failed'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_notification_events_pending
+    ON notification_events (next_attempt_at)
+    WHERE status = 'pending';
+
+-- Satu notifikasi per penerima per event: event yang diproses ulang tidak menggandakan notifikasi
+ALTER TABLE notifications
+    ADD COLUMN IF NOT EXISTS event_id UUID REFERENCES notification_events(id);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_notifications_event_user
+    ON notifications (event_id, user_id)
+    WHERE event_id IS NOT NULL;
@@ -4,16 +4,18 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
+	"time"
 
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 )
 
 var DB *sql.DB
 
-func ConnectPostgres() {
-	dsn := "host=localhost port=5432 user=postgres password=postgres dbname=prestasi_db sslmode=disable"
+// PostgresDSN dipakai juga oleh koneksi LISTEN (Listen)
+const PostgresDSN = "host=localhost port=5432 user=postgres password=postgres dbname=prestasi_db sslmode=disable"
 
-	db, err := sql.Open("postgres", dsn)
+func ConnectPostgres() {
+	db, err := sql.Open("postgres", PostgresDSN)
 	if err != nil {
 		log.Fatal("failed connect db:", err)
 	}
@@ -25,3 +27,18 @@ func ConnectPostgres() {
 	DB = db
 	fmt.Println("PostgreSQL connected")
 }
+
+// Listen koneksi LISTEN khusus (di luar pool DB). Setelah koneksi putus lalu tersambung lagi,
+// Notify menerima nil: notifikasi selama putus hilang dan pemanggil harus mengejar dari tabel.
+func Listen(channel string) (*pq.Listener, error) {
+	l := pq.NewListener(PostgresDSN, time.Second, time.Minute, func(ev pq.ListenerEventType, err error) {
+		if err != nil {
+			log.Println("postgres listener:", channel, err)
+		}
+	})
+	if err := l.Listen(channel); err != nil {
+		l.Close()
+		return nil, err
+	}
+	return l, nil
+}
@@ -0,0 +1,80 @@
+package mailer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrDisabled dikembalikan NoopSender: email tidak dikonfigurasi.
+var ErrDisabled = errors.New("mailer disabled")
+
+// Message satu email; Text dan HTML dikirim sebagai multipart/alternative.
+type Message struct {
+	To      string
+	ToName  string
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// Sender pengirim email.
+type Sender interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// NoopSender default saat MAIL_DRIVER kosong / none.
+type NoopSender struct{}
+
+func (NoopSender) Send(ctx context.Context, msg Message) error {
+	return ErrDisabled
+}
+
+// Enabled false untuk NoopSender
+func Enabled(s Sender) bool {
+	_, noop := s.(NoopSender)
+	return s != nil && !noop
+}
+
+// NewFromEnv pilih pengirim dari MAIL_DRIVER (none | smtp), default none.
+// smtp memakai SMTP_HOST, SMTP_PORT, SMTP_USERNAME, SMTP_PASSWORD, SMTP_FROM, SMTP_FROM_NAME,
+// SMTP_TLS (none | starttls | tls) dan SMTP_TIMEOUT.
+func NewFromEnv() (Sender, error) {
+	switch driver := strings.ToLower(os.Getenv("MAIL_DRIVER")); driver {
+	case "", "none":
+		return NoopSender{}, nil
+	case "smtp":
+		port := 25
+		if v := os.Getenv("SMTP_PORT"); v != "" {
+			p, err := strconv.Atoi(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid SMTP_PORT: %w", err)
+			}
+			port = p
+		}
+		timeout := 30 * time.Second
+		if v := os.Getenv("SMTP_TIMEOUT"); v != "" {
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid SMTP_TIMEOUT: %w", err)
+			}
+			timeout = d
+		}
+		return NewSMTPSender(SMTPConfig{
+			Host:     os.Getenv("SMTP_HOST"),
+			Port:     port,
+			Username: os.Getenv("SMTP_USERNAME"),
+			Password: os.Getenv("SMTP_PASSWORD"),
+			From:     os.Getenv("SMTP_FROM"),
+			FromName: os.Getenv("SMTP_FROM_NAME"),
+			TLS:      strings.ToLower(os.Getenv("SMTP_TLS")),
+			Timeout:  timeout,
+		})
+	default:
+		return nil, fmt.Errorf("unknown MAIL_DRIVER %q", driver)
+	}
+}
@@ -0,0 +1,96 @@
+package mailer
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	texttemplate "text/template"
+)
+
+// template email; tiap file mendefinisikan blok "subject", "text" dan "html"
+const (
+	TemplateSubmitted        = "submitted"
+	TemplateAwaitingApproval = "awaiting_approval"
+	TemplateVerified         = "verified"
+	TemplateRejected         = "rejected"
+	TemplatePasswordReset    = "password_reset"
+)
+
+// bahasa template yang tersedia; bahasa lain jatuh ke DefaultLanguage
+const (
+	LanguageID      = "id"
+	LanguageEN      = "en"
+	DefaultLanguage = LanguageID
+)
+
+// TemplateData isi template; field kosong dilewati oleh template
+type TemplateData struct {
+	RecipientName string
+	ActorName     string
+	Note          string
+	URL           string
+	// masa berlaku tautan (password reset), mis. "30 menit"
+	ExpiresIn string
+	// Reviewer penerima adalah reviewer tahap (awaiting_approval), bukan mahasiswa pemilik
+	Reviewer bool
+}
+
+//go:embed templates/*/*.tmpl
+var templateFS embed.FS
+
+type templateSet struct {
+	text *texttemplate.Template
+	html *htmltemplate.Template
+}
+
+var templates = mustLoadTemplates()
+
+func mustLoadTemplates() map[string]templateSet {
+	out := map[string]templateSet{}
+	for _, lang := range []string{LanguageID, LanguageEN} {
+		for _, name := range []string{TemplateSubmitted, TemplateAwaitingApproval, TemplateVerified, TemplateRejected, TemplatePasswordReset} {
+			path := "templates/" + lang + "/" + name + ".tmpl"
+			// subject dan text tanpa escape HTML, html di-escape kontekstual
+			text := texttemplate.Must(texttemplate.New(name).ParseFS(templateFS, path))
+			html := htmltemplate.Must(htmltemplate.New(name).ParseFS(templateFS, path))
+			out[lang+"/"+name] = templateSet{text: text, html: html}
+		}
+	}
+	return out
+}
+
+// SupportedLanguage true jika ada template untuk bahasa tersebut
+func SupportedLanguage(lang string) bool {
+	return lang == LanguageID || lang == LanguageEN
+}
+
+// Render isi Subject, Text dan HTML dari template; To / ToName diisi pemanggil.
+func Render(name, lang string, data TemplateData) (Message, error) {
+	if !SupportedLanguage(lang) {
+		lang = DefaultLanguage
+	}
+	set, ok := templates[lang+"/"+name]
+	if !ok {
+		return Message{}, fmt.Errorf("unknown email template %q", name)
+	}
+
+	var subject, text, html bytes.Buffer
+	if err := set.text.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return Message{}, err
+	}
+	if err := set.text.ExecuteTemplate(&text, "text", data); err != nil {
+		return Message{}, err
+	}
+	if err := set.html.ExecuteTemplate(&html, "html", data); err != nil {
+		return Message{}, err
+	}
+
+	return Message{
+		// subject satu baris; newline dari data tidak boleh ikut
+		Subject: strings.Join(strings.Fields(subject.String()), " "),
+		Text:    strings.TrimSpace(text.String()) + "\n",
+		HTML:    strings.TrimSpace(html.String()) + "\n",
+	}, nil
+}
@@ -1,10 +1,17 @@
 package main
 
 import (
+	"log"
+	"time"
+
 	"project_uas/app/repository"
 	"project_uas/app/service"
 	"project_uas/database"
+	"project_uas/mailer"
+	"project_uas/middleware"
 	"project_uas/routes"
+	"project_uas/scanner"
+	"project_uas/storage"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
@@ -28,12 +35,17 @@ import (
 func main() {
 	_ = godotenv.Load()
 	
-	app := fiber.New()
+	attachmentPolicy := service.AttachmentPolicyFromEnv()
+
+	app := fiber.New(fiber.Config{
+		// default Fiber 4 MB; disesuaikan dengan batas lampiran terbesar
+		BodyLimit: attachmentPolicy.BodyLimit(),
+	})
 	
 	// ✅ CORS biar Swagger UI bisa call API
 	app.Use(cors.New(cors.Config{
 		AllowOrigins: "*",
-		AllowHeaders: "Origin, Content-Type, Accept, Authorization",
+		AllowHeaders: "Origin, Content-Type, Accept, Authorization, Last-Event-ID",
 	}))
 	
 	// ✅ Swagger UI
@@ -43,31 +55,122 @@ func main() {
 
 
 	database.ConnectPostgres()
+	database.Migrate(database.DB)
 	database.ConnectMongo()
 
 	achievementRepo := repository.NewAchievementRepository(database.DB)
 	achievementMongoRepo := repository.NewAchievementMongoRepository(database.MongoDB)
-	achievementService := service.NewAchievementService(achievementRepo, achievementMongoRepo)
+	if err := achievementMongoRepo.EnsureSearchIndex(); err != nil {
+		log.Println("failed to ensure achievements text index:", err)
+	}
+	// lampiran lama belum punya id stabil
+	if n, err := achievementMongoRepo.BackfillAttachmentIDs(); err != nil {
+		log.Println("failed to backfill attachment ids:", err)
+	} else if n > 0 {
+		log.Println("backfilled attachment ids:", n)
+	}
+	// filter tipe/poin/tag list memakai salinan di achievement_references
+	if n, err := service.BackfillAchievementSummaries(achievementRepo, achievementMongoRepo); err != nil {
+		log.Println("failed to backfill achievement summaries:", err)
+	} else if n > 0 {
+		log.Println("backfilled achievement summaries:", n)
+	}
+	outboxRepo := repository.NewOutboxRepository(database.DB)
+	outboxProcessor := service.NewOutboxProcessor(outboxRepo, achievementMongoRepo)
+	achievementTypeRepo := repository.NewAchievementTypeRepository(database.DB)
+	approvalStageRepo := repository.NewApprovalStageRepository(database.DB)
+
+	// storage lampiran: STORAGE_DRIVER=local (default) | s3
+	attachmentStorage, err := storage.NewFromEnv()
+	if err != nil {
+		log.Fatal("storage init error:", err)
+	}
+	// scanner virus lampiran: SCANNER_DRIVER=none (default) | clamav
+	attachmentScanner, err := scanner.NewFromEnv()
+	if err != nil {
+		log.Fatal("scanner init error:", err)
+	}
+	// pengirim email: MAIL_DRIVER=none (default) | smtp
+	mailSender, err := mailer.NewFromEnv()
+	if err != nil {
+		log.Fatal("mailer init error:", err)
+	}
+	notificationRepo := repository.NewNotificationRepository(database.DB)
+	var availableChannels []service.NotificationChannel
+	// juga dipakai email reset password, walaupun "email" tidak ada di NOTIFICATION_CHANNELS
+	var emailChannel *service.EmailChannel
+	if mailer.Enabled(mailSender) {
+		emailRepo := repository.NewEmailRepository(database.DB)
+		emailDispatcher := service.NewEmailDispatcher(emailRepo, mailSender)
+		emailChannel = service.NewEmailChannel(emailRepo, notificationRepo, emailDispatcher)
+		availableChannels = append(availableChannels, emailChannel)
+		// worker email: retry dengan backoff, dead letter setelah EMAIL_MAX_ATTEMPTS
+		go emailDispatcher.Run(15*time.Second, nil)
+	}
+
+	// event alur prestasi -> notifikasi (async, tidak memperlambat handler)
+	// NOTIFICATION_CHANNELS: kanal tambahan selain in-app (log, email)
+	notificationChannels, err := service.NotificationChannelsFromEnv(availableChannels...)
+	if err != nil {
+		log.Fatal("notification channels error:", err)
+	}
+	notificationService := service.NewNotificationService(notificationRepo, notificationChannels...)
+	eventBus := service.NewEventBus(1024)
+	eventBus.Subscribe(notificationService.HandleEvent)
+	// worker notifikasi: notification_events ditulis di transaksi transisi / komentar, retry dengan backoff
+	go notificationService.Run(15*time.Second, nil)
+	// webhook keluar: delivery ditulis di transaksi transisi, event bus hanya memicu kirim; retry oleh Run
+	webhookService := service.NewWebhookService(repository.NewWebhookRepository(database.DB), achievementMongoRepo)
+	eventBus.Subscribe(webhookService.HandleEvent)
+	go webhookService.Run(15*time.Second, nil)
+
+	// SSE /events: LISTEN achievement_stream, siarkan ke client SSE di replika ini
+	streamRepo := repository.NewAchievementStreamRepository(database.DB)
+	streamHub := service.NewStreamHub(streamRepo)
+	streamListener, err := database.Listen(repository.StreamChannel)
+	if err != nil {
+		log.Fatal("event stream listen error:", err)
+	}
+	go streamHub.Run(streamListener.Notify, nil)
+	eventStreamService := service.NewEventStreamService(streamHub, streamRepo, achievementRepo)
+	go eventBus.Run(nil)
+
+	achievementService := service.NewAchievementService(achievementRepo, achievementMongoRepo, achievementTypeRepo, approvalStageRepo, repository.NewAchievementCommentRepository(database.DB), attachmentStorage, attachmentScanner, attachmentPolicy, service.VerificationSLAFromEnv(), outboxProcessor, eventBus)
+	achievementTypeService := service.NewAchievementTypeService(achievementTypeRepo)
+	approvalStageService := service.NewApprovalStageService(approvalStageRepo)
+
+	// worker outbox: terapkan perubahan Mongo yang tertunda (mis. Mongo sempat down)
+	go outboxProcessor.Run(5*time.Second, nil)
+
+	// token store: rotasi refresh token + pencabutan access token (cache disinkronkan lewat LISTEN)
+	tokenStore := service.NewTokenStore(repository.NewTokenRepository(database.DB))
+	tokenListener, err := database.Listen(repository.TokenRevocationChannel)
+	if err != nil {
+		log.Fatal("token store listen error:", err)
+	}
+	// LISTEN dulu baru muat cache supaya pencabutan di antaranya tidak terlewat
+	if err := tokenStore.Load(); err != nil {
+		log.Fatal("token store load error:", err)
+	}
+	go tokenStore.Run(tokenListener.Notify, nil)
+	middleware.UseRevocationChecker(tokenStore)
 
 	authRepo := repository.NewAuthRepository(database.DB)
-	authService := service.NewAuthService(authRepo)
+	authService := service.NewAuthService(authRepo, tokenStore, emailChannel)
 
 	userRepo := repository.NewUserRepository(database.DB)
-	userService := service.NewUserService(userRepo)
+	userService := service.NewUserService(userRepo, tokenStore)
 
 	studentRepo := repository.NewStudentRepository(database.DB)
 	studentService := service.NewStudentService(studentRepo)
 
 	lecturerRepo := repository.NewLecturerRepository(database.DB)
-	lecturerService := service.NewLecturerService(lecturerRepo)
+	lecturerService := service.NewLecturerService(lecturerRepo, repository.NewReviewDelegationRepository(database.DB))
 
 	reportMongoRepo := repository.NewReportMongoRepository(database.MongoDB)
 	reportService := service.NewReportService(studentRepo, lecturerRepo, reportMongoRepo)
 
-	routes.RegisterRoutes(app, authService, achievementService, userService, studentService, lecturerService, reportService)
-
-	// serve uploads
-	app.Static("/uploads", "./uploads")
+	routes.RegisterRoutes(app, authService, achievementService, achievementTypeService, approvalStageService, userService, studentService, lecturerService, reportService, notificationService, webhookService, eventStreamService)
 
 	app.Listen(":3000")
 }
@@ -8,6 +8,18 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 )
 
+// TokenRevocationChecker cek pencabutan access token (logout, logout semua perangkat, user nonaktif)
+type TokenRevocationChecker interface {
+	AccessTokenRevoked(claims jwt.MapClaims) bool
+}
+
+var revocationChecker TokenRevocationChecker
+
+// UseRevocationChecker dipasang dari main; nil = hanya cek signature dan exp
+func UseRevocationChecker(checker TokenRevocationChecker) {
+	revocationChecker = checker
+}
+
 func JWTMiddleware(c *fiber.Ctx) error {
 	authHeader := c.Get("Authorization")
 	if authHeader == "" {
@@ -32,6 +44,10 @@ func JWTMiddleware(c *fiber.Ctx) error {
 		return c.Status(401).JSON(fiber.Map{"message": "invalid or expired token"})
 	}
 
+	if claims, ok := token.Claims.(jwt.MapClaims); ok && revocationChecker != nil && revocationChecker.AccessTokenRevoked(claims) {
+		return c.Status(401).JSON(fiber.Map{"message": "token has been revoked"})
+	}
+
 	c.Locals("user", token)
 	return c.Next()
 }
@@ -17,6 +17,39 @@ func AchievementRoutes(app *fiber.App, svc *service.AchievementService) {
 		svc.GetAchievements,
 	)
 
+	// harus sebelum /:id
+	app.Get(base+"/search",
+		middleware.JWTMiddleware,
+		middleware.RequirePermission("achievement:read"),
+		svc.SearchAchievements,
+	)
+
+	app.Post(base+"/bulk/verify",
+		middleware.JWTMiddleware,
+		middleware.RequirePermission("achievement:verify"),
+		svc.BulkVerifyAchievements,
+	)
+
+	app.Post(base+"/bulk/reject",
+		middleware.JWTMiddleware,
+		middleware.RequirePermission("achievement:verify"),
+		svc.BulkRejectAchievements,
+	)
+
+	// antrean verifikasi Dosen Wali (harus sebelum /:id)
+	app.Get(base+"/queue",
+		middleware.JWTMiddleware,
+		middleware.RequirePermission("achievement:verify"),
+		svc.GetVerificationQueue,
+	)
+
+	// antrean reviewer tahap persetujuan; permission tahap dicek per item (harus sebelum /:id)
+	app.Get(base+"/approvals",
+		middleware.JWTMiddleware,
+		middleware.RequirePermission("achievement:read"),
+		svc.GetApprovalQueue,
+	)
+
 	app.Get(base+"/:id",
 		middleware.JWTMiddleware,
 		middleware.RequirePermission("achievement:read"),
@@ -47,6 +80,12 @@ func AchievementRoutes(app *fiber.App, svc *service.AchievementService) {
 		svc.SubmitAchievement,
 	)
 
+	app.Post(base+"/:id/revise",
+		middleware.JWTMiddleware,
+		middleware.RequirePermission("achievement:update"),
+		svc.ReviseAchievement,
+	)
+
 	app.Post(base+"/:id/verify",
 		middleware.JWTMiddleware,
 		middleware.RequirePermission("achievement:verify"),
@@ -59,15 +98,87 @@ func AchievementRoutes(app *fiber.App, svc *service.AchievementService) {
 		svc.RejectAchievement,
 	)
 
+	app.Post(base+"/:id/override/verify",
+		middleware.JWTMiddleware,
+		middleware.RequirePermission("achievement:override"),
+		svc.OverrideVerifyAchievement,
+	)
+
+	app.Post(base+"/:id/override/reject",
+		middleware.JWTMiddleware,
+		middleware.RequirePermission("achievement:override"),
+		svc.OverrideRejectAchievement,
+	)
+
+	// permission tahap (mis. achievement:approve_faculty) dicek di service sesuai tahap berjalan
+	app.Post(base+"/:id/approval/approve",
+		middleware.JWTMiddleware,
+		middleware.RequirePermission("achievement:read"),
+		svc.ApproveAchievementStage,
+	)
+
+	app.Post(base+"/:id/approval/reject",
+		middleware.JWTMiddleware,
+		middleware.RequirePermission("achievement:read"),
+		svc.RejectAchievementStage,
+	)
+
 	app.Get(base+"/:id/history",
 		middleware.JWTMiddleware,
 		middleware.RequirePermission("achievement:read"),
 		svc.GetAchievementHistory,
 	)
 
+	app.Get(base+"/:id/comments",
+		middleware.JWTMiddleware,
+		middleware.RequirePermission("achievement:read"),
+		svc.GetAchievementComments,
+	)
+
+	app.Post(base+"/:id/comments",
+		middleware.JWTMiddleware,
+		middleware.RequirePermission("achievement:read"),
+		svc.CreateAchievementComment,
+	)
+
 	app.Post(base+"/:id/attachments",
 		middleware.JWTMiddleware,
 		middleware.RequirePermission("achievement:update"),
 		svc.UploadAchievementAttachment,
 	)
+
+	app.Get(base+"/:id/attachments/:attachmentId",
+		middleware.JWTMiddleware,
+		middleware.RequirePermission("achievement:read"),
+		svc.DownloadAchievementAttachment,
+	)
+
+	app.Get(base+"/:id/attachments/:attachmentId/thumbnail",
+		middleware.JWTMiddleware,
+		middleware.RequirePermission("achievement:read"),
+		svc.DownloadAchievementAttachmentThumbnail,
+	)
+
+	app.Put(base+"/:id/attachments/:attachmentId",
+		middleware.JWTMiddleware,
+		middleware.RequirePermission("achievement:update"),
+		svc.ReplaceAchievementAttachment,
+	)
+
+	app.Delete(base+"/:id/attachments/:attachmentId",
+		middleware.JWTMiddleware,
+		middleware.RequirePermission("achievement:update"),
+		svc.DeleteAchievementAttachment,
+	)
+
+	app.Post(base+"/:id/attachments/:attachmentId/signed-url",
+		middleware.JWTMiddleware,
+		middleware.RequirePermission("achievement:read"),
+		svc.CreateAttachmentSignedURL,
+	)
+
+	// tanpa JWT: otorisasi lewat signature + expires di query
+	app.Get(base+"/:id/attachments/:attachmentId/signed",
+		svc.DownloadSignedAttachment,
+	)
 }
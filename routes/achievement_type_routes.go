@@ -0,0 +1,43 @@
+package routes
+
+import (
+	"project_uas/app/service"
+	"project_uas/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func AchievementTypeRoutes(app *fiber.App, svc *service.AchievementTypeService) {
+	base := "/api/v1/achievement-types"
+
+	// dibaca semua role (mahasiswa butuh daftar tipe untuk membuat prestasi)
+	app.Get(base,
+		middleware.JWTMiddleware,
+		middleware.RequirePermission("achievement:read"),
+		svc.ListAchievementTypes,
+	)
+
+	app.Get(base+"/:code",
+		middleware.JWTMiddleware,
+		middleware.RequirePermission("achievement:read"),
+		svc.GetAchievementType,
+	)
+
+	app.Post(base,
+		middleware.JWTMiddleware,
+		middleware.RequirePermission("achievement_type:manage"),
+		svc.CreateAchievementType,
+	)
+
+	app.Put(base+"/:code",
+		middleware.JWTMiddleware,
+		middleware.RequirePermission("achievement_type:manage"),
+		svc.UpdateAchievementType,
+	)
+
+	app.Delete(base+"/:code",
+		middleware.JWTMiddleware,
+		middleware.RequirePermission("achievement_type:manage"),
+		svc.DeleteAchievementType,
+	)
+}
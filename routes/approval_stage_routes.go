@@ -0,0 +1,42 @@
+package routes
+
+import (
+	"project_uas/app/service"
+	"project_uas/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func ApprovalStageRoutes(app *fiber.App, svc *service.ApprovalStageService) {
+	base := "/api/v1/approval-stages"
+
+	app.Get(base,
+		middleware.JWTMiddleware,
+		middleware.RequirePermission("approval_stage:manage"),
+		svc.ListApprovalStages,
+	)
+
+	app.Get(base+"/:code",
+		middleware.JWTMiddleware,
+		middleware.RequirePermission("approval_stage:manage"),
+		svc.GetApprovalStage,
+	)
+
+	app.Post(base,
+		middleware.JWTMiddleware,
+		middleware.RequirePermission("approval_stage:manage"),
+		svc.CreateApprovalStage,
+	)
+
+	app.Put(base+"/:code",
+		middleware.JWTMiddleware,
+		middleware.RequirePermission("approval_stage:manage"),
+		svc.UpdateApprovalStage,
+	)
+
+	app.Delete(base+"/:code",
+		middleware.JWTMiddleware,
+		middleware.RequirePermission("approval_stage:manage"),
+		svc.DeleteApprovalStage,
+	)
+}
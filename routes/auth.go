@@ -12,12 +12,19 @@ func AuthRoutes(app *fiber.App, authService *service.AuthService) {
 
 	app.Post(base+"/login", authService.Login)
 	app.Post(base+"/refresh", authService.Refresh)
+	app.Post(base+"/password/forgot", authService.ForgotPassword)
+	app.Post(base+"/password/reset", authService.ResetPassword)
 
 	app.Post(base+"/logout",
 		middleware.JWTMiddleware,
 		authService.Logout,
 	)
 
+	app.Post(base+"/logout-all",
+		middleware.JWTMiddleware,
+		authService.LogoutAll,
+	)
+
 	app.Get(base+"/profile",
 		middleware.JWTMiddleware,
 		authService.Profile,
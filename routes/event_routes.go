@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"project_uas/app/service"
+	"project_uas/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// EventRoutes stream SSE perubahan prestasi (scope sesuai role)
+func EventRoutes(app *fiber.App, svc *service.EventStreamService) {
+	app.Get("/api/v1/events",
+		middleware.JWTMiddleware,
+		svc.StreamEvents,
+	)
+}
@@ -21,4 +21,22 @@ func LecturerRoutes(app *fiber.App, svc *service.LecturerService) {
 		middleware.RequirePermission("student:read"),
 		svc.GetAdvisees,
 	)
+
+	app.Get(base+"/:id/delegations",
+		middleware.JWTMiddleware,
+		middleware.RequirePermission("review_delegation:manage"),
+		svc.GetDelegations,
+	)
+
+	app.Post(base+"/:id/delegations",
+		middleware.JWTMiddleware,
+		middleware.RequirePermission("review_delegation:manage"),
+		svc.CreateDelegation,
+	)
+
+	app.Delete(base+"/:id/delegations/:delegationId",
+		middleware.JWTMiddleware,
+		middleware.RequirePermission("review_delegation:manage"),
+		svc.RevokeDelegation,
+	)
 }
@@ -0,0 +1,34 @@
+package routes
+
+import (
+	"project_uas/app/service"
+	"project_uas/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// NotificationRoutes notifikasi milik user yang login (semua role)
+func NotificationRoutes(app *fiber.App, svc *service.NotificationService) {
+	base := "/api/v1/notifications"
+
+	app.Get(base,
+		middleware.JWTMiddleware,
+		svc.GetNotifications,
+	)
+
+	// sebelum /:id supaya "preferences" tidak dianggap ID
+	app.Get(base+"/preferences",
+		middleware.JWTMiddleware,
+		svc.GetNotificationPreferences,
+	)
+
+	app.Put(base+"/preferences",
+		middleware.JWTMiddleware,
+		svc.UpdateNotificationPreferences,
+	)
+
+	app.Post(base+"/:id/read",
+		middleware.JWTMiddleware,
+		svc.MarkNotificationRead,
+	)
+}
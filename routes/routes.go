@@ -10,16 +10,26 @@ func RegisterRoutes(
 	app *fiber.App,
 	authService *service.AuthService,
 	achievementService *service.AchievementService,
+	achievementTypeService *service.AchievementTypeService,
+	approvalStageService *service.ApprovalStageService,
 	userService *service.UserService,
 	studentService *service.StudentService,
 	lecturerService *service.LecturerService,
 	reportService *service.ReportService,
+	notificationService *service.NotificationService,
+	webhookService *service.WebhookService,
+	eventStreamService *service.EventStreamService,
 ) {
 	AuthRoutes(app, authService)
 	AchievementRoutes(app, achievementService)
+	AchievementTypeRoutes(app, achievementTypeService)
+	ApprovalStageRoutes(app, approvalStageService)
 
 	UserRoutes(app, userService)
 	StudentRoutes(app, studentService)
 	LecturerRoutes(app, lecturerService)
 	ReportRoutes(app, reportService)
+	NotificationRoutes(app, notificationService)
+	WebhookRoutes(app, webhookService)
+	EventRoutes(app, eventStreamService)
 }
@@ -0,0 +1,61 @@
+package routes
+
+import (
+	"project_uas/app/service"
+	"project_uas/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// WebhookRoutes langganan webhook integrasi (admin)
+func WebhookRoutes(app *fiber.App, svc *service.WebhookService) {
+	base := "/api/v1/webhooks"
+
+	app.Get(base,
+		middleware.JWTMiddleware,
+		middleware.RequirePermission("webhook:manage"),
+		svc.ListWebhooks,
+	)
+
+	app.Get(base+"/:id",
+		middleware.JWTMiddleware,
+		middleware.RequirePermission("webhook:manage"),
+		svc.GetWebhook,
+	)
+
+	app.Post(base,
+		middleware.JWTMiddleware,
+		middleware.RequirePermission("webhook:manage"),
+		svc.CreateWebhook,
+	)
+
+	app.Put(base+"/:id",
+		middleware.JWTMiddleware,
+		middleware.RequirePermission("webhook:manage"),
+		svc.UpdateWebhook,
+	)
+
+	app.Delete(base+"/:id",
+		middleware.JWTMiddleware,
+		middleware.RequirePermission("webhook:manage"),
+		svc.DeleteWebhook,
+	)
+
+	app.Get(base+"/:id/deliveries",
+		middleware.JWTMiddleware,
+		middleware.RequirePermission("webhook:manage"),
+		svc.ListWebhookDeliveries,
+	)
+
+	app.Get(base+"/:id/deliveries/:deliveryId",
+		middleware.JWTMiddleware,
+		middleware.RequirePermission("webhook:manage"),
+		svc.GetWebhookDelivery,
+	)
+
+	app.Post(base+"/:id/deliveries/:deliveryId/redeliver",
+		middleware.JWTMiddleware,
+		middleware.RequirePermission("webhook:manage"),
+		svc.RedeliverWebhook,
+	)
+}
@@ -0,0 +1,147 @@
+package scanner
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+const clamChunkSize = 32 * 1024
+
+// ClamAVScanner kirim file ke clamd lewat perintah INSTREAM (TCP atau unix socket).
+type ClamAVScanner struct {
+	Network string
+	Address string
+	Timeout time.Duration
+}
+
+// NewClamAVScanner terima "tcp://host:port", "unix:/path" atau "host:port" (default 127.0.0.1:3310).
+func NewClamAVScanner(address string, timeout time.Duration) (*ClamAVScanner, error) {
+	network, addr := "tcp", address
+	switch {
+	case address == "":
+		addr = "127.0.0.1:3310"
+	case strings.HasPrefix(address, "unix:"):
+		network, addr = "unix", strings.TrimPrefix(strings.TrimPrefix(address, "unix:"), "//")
+	case strings.HasPrefix(address, "tcp://"):
+		addr = strings.TrimPrefix(address, "tcp://")
+	}
+	if addr == "" {
+		return nil, fmt.Errorf("invalid CLAMAV_ADDRESS %q", address)
+	}
+	return &ClamAVScanner{Network: network, Address: addr, Timeout: timeout}, nil
+}
+
+func (s *ClamAVScanner) Scan(ctx context.Context, r io.Reader) (Result, error) {
+	conn, err := s.dial(ctx)
+	if err != nil {
+		return Result{}, err
+	}
+	defer conn.Close()
+
+	if _, err := conn.Write([]byte("zINSTREAM\x00")); err != nil {
+		return Result{}, err
+	}
+
+	// format INSTREAM: [panjang 4 byte big-endian][data] ... diakhiri chunk panjang 0
+	buf := make([]byte, clamChunkSize)
+	size := make([]byte, 4)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			binary.BigEndian.PutUint32(size, uint32(n))
+			if _, werr := conn.Write(size); werr != nil {
+				return Result{}, werr
+			}
+			if _, werr := conn.Write(buf[:n]); werr != nil {
+				return Result{}, werr
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return Result{}, err
+		}
+	}
+	if _, err := conn.Write([]byte{0, 0, 0, 0}); err != nil {
+		return Result{}, err
+	}
+
+	reply, err := readReply(conn)
+	if err != nil {
+		return Result{}, err
+	}
+	return parseReply(reply)
+}
+
+// Ping cek clamd hidup (balasan PONG).
+func (s *ClamAVScanner) Ping(ctx context.Context) error {
+	conn, err := s.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.Write([]byte("zPING\x00")); err != nil {
+		return err
+	}
+	reply, err := readReply(conn)
+	if err != nil {
+		return err
+	}
+	if reply != "PONG" {
+		return fmt.Errorf("clamd: unexpected reply %q", reply)
+	}
+	return nil
+}
+
+func (s *ClamAVScanner) dial(ctx context.Context) (net.Conn, error) {
+	d := net.Dialer{Timeout: s.Timeout}
+	conn, err := d.DialContext(ctx, s.Network, s.Address)
+	if err != nil {
+		return nil, err
+	}
+	var deadline time.Time
+	if s.Timeout > 0 {
+		deadline = time.Now().Add(s.Timeout)
+	}
+	if dl, ok := ctx.Deadline(); ok && (deadline.IsZero() || dl.Before(deadline)) {
+		deadline = dl
+	}
+	if !deadline.IsZero() {
+		_ = conn.SetDeadline(deadline)
+	}
+	return conn, nil
+}
+
+// readReply baca balasan clamd yang diakhiri NUL (mode perintah "z").
+func readReply(r io.Reader) (string, error) {
+	reply, err := bufio.NewReader(r).ReadBytes(0)
+	if err != nil && !(err == io.EOF && len(reply) > 0) {
+		return "", err
+	}
+	return string(bytes.TrimRight(reply, "\x00\n")), nil
+}
+
+// parseReply: "stream: OK", "stream: <signature> FOUND", atau "... ERROR".
+func parseReply(reply string) (Result, error) {
+	msg := strings.TrimSpace(strings.TrimPrefix(reply, "stream:"))
+	switch {
+	case msg == "OK":
+		return Result{Scanned: true}, nil
+	case strings.HasSuffix(msg, " FOUND"):
+		return Result{Scanned: true, Infected: true, Signature: strings.TrimSuffix(msg, " FOUND")}, nil
+	case strings.HasSuffix(msg, " ERROR"):
+		return Result{}, errors.New("clamd: " + strings.TrimSuffix(msg, " ERROR"))
+	default:
+		return Result{}, fmt.Errorf("clamd: unexpected reply %q", reply)
+	}
+}
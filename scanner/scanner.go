@@ -0,0 +1,50 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// Scanner pemeriksa virus/malware untuk file lampiran sebelum disimpan.
+type Scanner interface {
+	Scan(ctx context.Context, r io.Reader) (Result, error)
+}
+
+// Result hasil scan. Scanned=false berarti tidak ada scanner aktif (NoopScanner).
+type Result struct {
+	Scanned   bool
+	Infected  bool
+	Signature string
+}
+
+// NoopScanner default: file tidak diperiksa.
+type NoopScanner struct{}
+
+func (NoopScanner) Scan(ctx context.Context, r io.Reader) (Result, error) {
+	return Result{}, nil
+}
+
+// NewFromEnv pilih scanner dari SCANNER_DRIVER (none | clamav), default none.
+// clamav memakai CLAMAV_ADDRESS (tcp://host:port atau unix:/path/clamd.sock) dan CLAMAV_TIMEOUT.
+func NewFromEnv() (Scanner, error) {
+	switch driver := strings.ToLower(os.Getenv("SCANNER_DRIVER")); driver {
+	case "", "none":
+		return NoopScanner{}, nil
+	case "clamav":
+		timeout := 30 * time.Second
+		if v := os.Getenv("CLAMAV_TIMEOUT"); v != "" {
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid CLAMAV_TIMEOUT: %w", err)
+			}
+			timeout = d
+		}
+		return NewClamAVScanner(os.Getenv("CLAMAV_ADDRESS"), timeout)
+	default:
+		return nil, fmt.Errorf("unknown SCANNER_DRIVER %q", driver)
+	}
+}
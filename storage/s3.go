@@ -0,0 +1,125 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// S3Storage driver untuk storage S3-compatible (AWS S3, MinIO, dll).
+// Bucket sebaiknya private; file dialirkan lewat API.
+type S3Storage struct {
+	Client *minio.Client
+	Bucket string
+}
+
+// NewS3StorageFromEnv baca S3_ENDPOINT, S3_ACCESS_KEY, S3_SECRET_KEY, S3_BUCKET,
+// S3_REGION, S3_USE_SSL (default true).
+func NewS3StorageFromEnv() (*S3Storage, error) {
+	endpoint := os.Getenv("S3_ENDPOINT")
+	bucket := os.Getenv("S3_BUCKET")
+	if endpoint == "" || bucket == "" {
+		return nil, errors.New("S3_ENDPOINT and S3_BUCKET are required for STORAGE_DRIVER=s3")
+	}
+
+	useSSL := true
+	if v := os.Getenv("S3_USE_SSL"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid S3_USE_SSL: %w", err)
+		}
+		useSSL = b
+	}
+	client, err := minio.New(endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(os.Getenv("S3_ACCESS_KEY"), os.Getenv("S3_SECRET_KEY"), ""),
+		Secure: useSSL,
+		Region: os.Getenv("S3_REGION"),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return NewS3Storage(client, bucket, os.Getenv("S3_REGION"))
+}
+
+// NewS3Storage membuat bucket jika belum ada (praktis untuk MinIO lokal).
+func NewS3Storage(client *minio.Client, bucket, region string) (*S3Storage, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	exists, err := client.BucketExists(ctx, bucket)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		if err := client.MakeBucket(ctx, bucket, minio.MakeBucketOptions{Region: region}); err != nil {
+			return nil, err
+		}
+	}
+	return &S3Storage{Client: client, Bucket: bucket}, nil
+}
+
+func (s *S3Storage) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	k, err := cleanKey(key)
+	if err != nil {
+		return err
+	}
+	_, err = s.Client.PutObject(ctx, s.Bucket, k, r, size, minio.PutObjectOptions{ContentType: contentType})
+	return err
+}
+
+func (s *S3Storage) Get(ctx context.Context, key string) (io.ReadCloser, *ObjectInfo, error) {
+	k, err := cleanKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	obj, err := s.Client.GetObject(ctx, s.Bucket, k, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+	// GetObject lazy: Stat memastikan object ada
+	st, err := obj.Stat()
+	if err != nil {
+		obj.Close()
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return nil, nil, ErrNotFound
+		}
+		return nil, nil, err
+	}
+	return obj, &ObjectInfo{Key: k, Size: st.Size, ContentType: st.ContentType, LastModified: st.LastModified}, nil
+}
+
+func (s *S3Storage) Delete(ctx context.Context, key string) error {
+	k, err := cleanKey(key)
+	if err != nil {
+		return err
+	}
+	return s.Client.RemoveObject(ctx, s.Bucket, k, minio.RemoveObjectOptions{})
+}
+
+func (s *S3Storage) List(ctx context.Context, prefix string) ([]ObjectInfo, error) {
+	k, err := cleanKey(prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []ObjectInfo
+	for obj := range s.Client.ListObjects(ctx, s.Bucket, minio.ListObjectsOptions{Prefix: k + "/", Recursive: true}) {
+		if obj.Err != nil {
+			return nil, obj.Err
+		}
+		out = append(out, ObjectInfo{
+			Key:          obj.Key,
+			Size:         obj.Size,
+			ContentType:  obj.ContentType,
+			LastModified: obj.LastModified,
+		})
+	}
+	return out, nil
+}
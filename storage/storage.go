@@ -0,0 +1,62 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"time"
+)
+
+var (
+	ErrNotFound   = errors.New("object not found")
+	ErrInvalidKey = errors.New("invalid storage key")
+)
+
+// Storage backend file lampiran. Key berupa path relatif dengan "/" (mis. achievements/<refID>/<file>).
+type Storage interface {
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, *ObjectInfo, error)
+	Delete(ctx context.Context, key string) error
+
+	// List semua object di bawah prefix (dipakai garbage collection file yatim)
+	List(ctx context.Context, prefix string) ([]ObjectInfo, error)
+}
+
+type ObjectInfo struct {
+	Key          string
+	Size         int64
+	ContentType  string
+	LastModified time.Time
+}
+
+// NewFromEnv pilih driver dari STORAGE_DRIVER (local | s3), default local.
+func NewFromEnv() (Storage, error) {
+	switch driver := strings.ToLower(os.Getenv("STORAGE_DRIVER")); driver {
+	case "", "local":
+		return NewLocalStorage(envOr("STORAGE_LOCAL_DIR", "uploads"))
+	case "s3":
+		return NewS3StorageFromEnv()
+	default:
+		return nil, fmt.Errorf("unknown STORAGE_DRIVER %q", driver)
+	}
+}
+
+// cleanKey tolak key absolut / yang keluar dari root (..).
+func cleanKey(key string) (string, error) {
+	k := path.Clean(strings.TrimPrefix(key, "/"))
+	if k == "." || k == ".." || strings.HasPrefix(k, "../") || strings.Contains(k, "\\") {
+		return "", ErrInvalidKey
+	}
+	return k, nil
+}
+
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
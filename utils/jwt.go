@@ -6,16 +6,25 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// masa berlaku token
+const (
+	AccessTokenTTL  = 2 * time.Hour
+	RefreshTokenTTL = 7 * 24 * time.Hour
 )
 
 type CustomClaims struct {
 	Role        string   `json:"role"`
 	Permissions []string `json:"permissions"`
+	// FamilyID sesi login (family refresh token); dicabut saat logout
+	FamilyID string `json:"fid,omitempty"`
 	jwt.RegisteredClaims
 }
 
-// Access token (tetap)
-func GenerateToken(userID string, role string, permissions []string) (string, error) {
+// Access token; jti dipakai untuk denylist saat logout
+func GenerateToken(userID string, role string, permissions []string, familyID string) (string, error) {
 	secret := os.Getenv("JWT_SECRET")
 	if secret == "" {
 		return "", errors.New("JWT_SECRET is not set")
@@ -24,9 +33,11 @@ func GenerateToken(userID string, role string, permissions []string) (string, er
 	claims := CustomClaims{
 		Role:        role,
 		Permissions: permissions,
+		FamilyID:    familyID,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
 			Subject:   userID,
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(2 * time.Hour)),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(AccessTokenTTL)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
 	}
@@ -36,31 +47,41 @@ func GenerateToken(userID string, role string, permissions []string) (string, er
 }
 
 // =====================
-// Refresh token (stateless)
+// Refresh token (dilacak di refresh_tokens per jti)
 // =====================
 
 type RefreshClaims struct {
-	Type string `json:"type"` // "refresh"
+	Type     string `json:"type"` // "refresh"
+	FamilyID string `json:"fid"`
 	jwt.RegisteredClaims
 }
 
-func GenerateRefreshToken(userID string) (string, error) {
+// GenerateRefreshToken terbitkan refresh token baru (jti acak) dalam family;
+// claims dikembalikan untuk dicatat di token store.
+func GenerateRefreshToken(userID, familyID string) (string, *RefreshClaims, error) {
 	secret := os.Getenv("JWT_SECRET")
 	if secret == "" {
-		return "", errors.New("JWT_SECRET is not set")
+		return "", nil, errors.New("JWT_SECRET is not set")
 	}
 
-	claims := RefreshClaims{
-		Type: "refresh",
+	now := time.Now()
+	claims := &RefreshClaims{
+		Type:     "refresh",
+		FamilyID: familyID,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
 			Subject:   userID,
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(7 * 24 * time.Hour)), // 7 hari
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ExpiresAt: jwt.NewNumericDate(now.Add(RefreshTokenTTL)),
+			IssuedAt:  jwt.NewNumericDate(now),
 		},
 	}
 
 	t := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return t.SignedString([]byte(secret))
+	signed, err := t.SignedString([]byte(secret))
+	if err != nil {
+		return "", nil, err
+	}
+	return signed, claims, nil
 }
 
 func ParseRefreshToken(tokenString string) (*RefreshClaims, error) {
@@ -82,5 +103,9 @@ func ParseRefreshToken(tokenString string) (*RefreshClaims, error) {
 	if claims.Subject == "" {
 		return nil, errors.New("invalid refresh token subject")
 	}
+	// refresh token lama (sebelum ada token store) tidak punya jti/family -> login ulang
+	if claims.ID == "" || claims.FamilyID == "" {
+		return nil, errors.New("refresh token is no longer supported")
+	}
 	return claims, nil
 }
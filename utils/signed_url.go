@@ -0,0 +1,56 @@
+package utils
+
+import (
+	"crypto/hkdf"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"strconv"
+	"time"
+)
+
+// =====================
+// Signed URL (link download berumur pendek tanpa header Authorization)
+// =====================
+
+// label HKDF: kunci signed URL turunan JWT_SECRET tidak pernah sama dengan kunci JWT
+const urlSigningLabel = "project_uas attachment signed url v1"
+
+// urlSigningKey ATTACHMENT_URL_SECRET jika diisi, kalau tidak diturunkan dari JWT_SECRET lewat HKDF.
+func urlSigningKey() ([]byte, error) {
+	if secret := os.Getenv("ATTACHMENT_URL_SECRET"); secret != "" {
+		return []byte(secret), nil
+	}
+	secret := os.Getenv("JWT_SECRET")
+	if secret == "" {
+		return nil, errors.New("ATTACHMENT_URL_SECRET or JWT_SECRET is not set")
+	}
+	return hkdf.Key(sha256.New, []byte(secret), nil, urlSigningLabel, 32)
+}
+
+func signPath(key []byte, path string, expires int64) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(path + "|" + strconv.FormatInt(expires, 10)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// SignPath tanda tangan HMAC-SHA256 untuk path + waktu kedaluwarsa (unix detik).
+func SignPath(path string, expires time.Time) (string, error) {
+	key, err := urlSigningKey()
+	if err != nil {
+		return "", err
+	}
+	return signPath(key, path, expires.Unix()), nil
+}
+
+// VerifyPathSignature cek signature dan belum kedaluwarsa.
+func VerifyPathSignature(path string, expires int64, signature string) bool {
+	key, err := urlSigningKey()
+	if err != nil || time.Now().Unix() > expires {
+		return false
+	}
+	expected := signPath(key, path, expires)
+	return hmac.Equal([]byte(expected), []byte(signature))
+}